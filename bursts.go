@@ -0,0 +1,236 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Burst is one phase of a process's work: Duration ticks on the CPU, or, when IO is set, Duration
+// ticks blocked on I/O, during which the CPU is free for other processes.
+type Burst struct {
+	Duration int64
+	IO       bool
+}
+
+// ProcessState is what a process was doing when the simulation stopped.
+type ProcessState int
+
+const (
+	StateNew     ProcessState = iota // not yet released
+	StateReady                       // released and waiting for the CPU
+	StateRunning                     // on the CPU
+	StateBlocked                     // doing I/O
+	StateExited                      // done
+)
+
+func (s ProcessState) String() string {
+	switch s {
+	case StateNew:
+		return "new"
+	case StateReady:
+		return "ready"
+	case StateRunning:
+		return "running"
+	case StateBlocked:
+		return "blocked"
+	case StateExited:
+		return "exited"
+	}
+	return fmt.Sprintf("ProcessState(%d)", int(s))
+}
+
+// parseBursts parses a burst sequence such as "4,io:3,2": comma-separated CPU durations, with an
+// "io:" prefix marking an I/O burst. The sequence must start and end with a CPU burst, so a
+// process always runs on arrival and exits on the CPU.
+func parseBursts(spec string) ([]Burst, error) {
+	var bursts []Burst
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		value, io := strings.CutPrefix(entry, "io:")
+		duration, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil || duration <= 0 {
+			return nil, fmt.Errorf("burst %q must be a positive duration, optionally prefixed with io:", entry)
+		}
+		bursts = append(bursts, Burst{Duration: duration, IO: io})
+	}
+	if bursts[0].IO || bursts[len(bursts)-1].IO {
+		return nil, fmt.Errorf("bursts %q must start and end with a CPU burst", spec)
+	}
+	return bursts, nil
+}
+
+// formatBursts is the inverse of parseBursts.
+func formatBursts(bursts []Burst) string {
+	entries := make([]string, len(bursts))
+	for i, b := range bursts {
+		entries[i] = fmt.Sprint(b.Duration)
+		if b.IO {
+			entries[i] = "io:" + entries[i]
+		}
+	}
+	return strings.Join(entries, ",")
+}
+
+// cpuTime is the total duration of the process's CPU bursts, which should equal its BurstDuration.
+func cpuTime(bursts []Burst) int64 {
+	var total int64
+	for _, b := range bursts {
+		if !b.IO {
+			total += b.Duration
+		}
+	}
+	return total
+}
+
+// hasIO reports whether the process blocks on I/O at some point.
+func (p Process) hasIO() bool {
+	for _, b := range p.Bursts {
+		if b.IO {
+			return true
+		}
+	}
+	return false
+}
+
+// hasIOBursts reports whether any process blocks on I/O.
+func hasIOBursts(processes []Process) bool {
+	for i := range processes {
+		if processes[i].hasIO() {
+			return true
+		}
+	}
+	return false
+}
+
+// cpuBurstLeft is what is left of the CPU burst the process is in with remaining ticks of CPU
+// left, which is all of them without Bursts.
+func (p Process) cpuBurstLeft(remaining int64) int64 {
+	var (
+		executed = p.BurstDuration - remaining
+		cpu      int64
+	)
+	for _, b := range p.Bursts {
+		if b.IO {
+			continue
+		}
+		if cpu += b.Duration; executed < cpu {
+			return cpu - executed
+		}
+	}
+	return remaining
+}
+
+// ioAfter is how long the process blocks on I/O once it has run for executed ticks: the I/O
+// bursts following the CPU burst that ends there, or zero if none ends there or no I/O follows.
+func (p Process) ioAfter(executed int64) int64 {
+	var (
+		cpu     int64
+		blocked int64
+		ended   bool // the CPU bursts so far end at executed
+	)
+	for _, b := range p.Bursts {
+		switch {
+		case b.IO && ended:
+			blocked += b.Duration
+		case b.IO:
+		case ended:
+			return blocked
+		default:
+			cpu += b.Duration
+			ended = cpu == executed
+		}
+	}
+	return 0 // the process exits after its last CPU burst
+}
+
+// ioBlocker tracks the I/O of processes for the schedulers that simulate their bursts, recording
+// each span a process spends blocked in its ProcessData.IO.
+type ioBlocker struct {
+	processes []Process
+	pd        []ProcessData
+}
+
+func newIOBlocker(processes []Process, pd []ProcessData) ioBlocker {
+	return ioBlocker{processes: processes, pd: pd}
+}
+
+// blocked reports whether process i is doing I/O during the tick starting at t, and so can
+// neither run nor wait.
+func (b ioBlocker) blocked(i int, t int64) bool {
+	spans := b.pd[i].IO
+	return len(spans) > 0 && spans[len(spans)-1].Start <= t && t < spans[len(spans)-1].Stop
+}
+
+// ran accounts for process i having run up to t with remaining ticks of CPU left. If that ends a
+// CPU burst followed by I/O, the process blocks from t and ran reports true, so the scheduler
+// frees the CPU.
+func (b ioBlocker) ran(i int, t, remaining int64) bool {
+	p := b.processes[i]
+	blocked := p.ioAfter(p.BurstDuration - remaining)
+	if blocked == 0 {
+		return false
+	}
+	b.pd[i].IO = append(b.pd[i].IO, TimeSlice{PID: p.ProcessID, Start: t, Stop: t + blocked})
+	return true
+}
+
+// ioTicks is how long the process has spent blocked on I/O.
+func (proc ProcessData) ioTicks() int64 {
+	var total int64
+	for _, span := range proc.IO {
+		total += span.Stop - span.Start
+	}
+	return total
+}
+
+// finalState is the state a process was left in when the clock stopped at elapsed.
+func finalState(p Process, proc ProcessData, gantt []TimeSlice, elapsed int64) ProcessState {
+	switch {
+	case proc.ExitTime != 0:
+		return StateExited
+	case len(proc.IO) > 0 && proc.IO[len(proc.IO)-1].Stop > elapsed:
+		return StateBlocked
+	case len(gantt) > 0 && gantt[len(gantt)-1].PID == p.ProcessID && gantt[len(gantt)-1].Stop == elapsed:
+		return StateRunning
+	case releaseTime(p) <= elapsed:
+		return StateReady
+	}
+	return StateNew
+}
+
+// ioIgnoredNote is the note of the schedulers that don't simulate I/O on processes that have some.
+const ioIgnoredNote = "I/O bursts not simulated: each process's CPU bursts ran back to back"
+
+// ioNotes explains that some of the algorithms that run ignore the processes' I/O bursts, naming
+// the registered algorithms that simulate them.
+func ioNotes(processes []Process, algos []algorithm) []string {
+	if !hasIOBursts(processes) {
+		return nil
+	}
+	var ignoring []string
+	for _, algo := range algos {
+		if !algo.io {
+			ignoring = append(ignoring, algo.title)
+		}
+	}
+	if len(ignoring) == 0 {
+		return nil
+	}
+	var simulating []string
+	for _, algo := range algorithms {
+		if algo.io {
+			simulating = append(simulating, algo.name)
+		}
+	}
+	only := "none of the algorithms simulates I/O bursts"
+	switch n := len(simulating); n {
+	case 0:
+	case 1:
+		only = "only " + simulating[0] + " simulates I/O bursts"
+	default:
+		only = "only " + strings.Join(simulating[:n-1], ", ") + " and " + simulating[n-1] + " simulate I/O bursts"
+	}
+	return []string{fmt.Sprintf("%s, so the other algorithms (%s) run each process's CPU bursts back to back",
+		only, strings.Join(ignoring, ", "))}
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_parseBursts(t *testing.T) {
+	t.Parallel()
+	got, err := parseBursts("4, io:3,2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Burst{{Duration: 4}, {Duration: 3, IO: true}, {Duration: 2}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseBursts() = %v, want %v", got, want)
+	}
+	if formatted := formatBursts(got); formatted != "4,io:3,2" {
+		t.Errorf("formatBursts() = %q, want %q", formatted, "4,io:3,2")
+	}
+
+	for _, spec := range []string{"", "4,,2", "4,io:0,2", "io:3,2", "4,io:3", "4,disk:3,2"} {
+		if _, err := parseBursts(spec); err == nil {
+			t.Errorf("parseBursts(%q) error = nil, want one", spec)
+		}
+	}
+}
+
+func TestProcess_ioAfter(t *testing.T) {
+	t.Parallel()
+	p := Process{BurstDuration: 6, Bursts: []Burst{{Duration: 1}, {Duration: 3}, {Duration: 2, IO: true}, {Duration: 1, IO: true}, {Duration: 2}}}
+	for executed, want := range []int64{0, 0, 0, 0, 3, 0, 0} {
+		if got := p.ioAfter(int64(executed)); got != want {
+			t.Errorf("ioAfter(%d) = %d, want %d", executed, got, want)
+		}
+	}
+}
+
+func TestProcess_cpuBurstLeft(t *testing.T) {
+	t.Parallel()
+	p := Process{BurstDuration: 6, Bursts: []Burst{{Duration: 1}, {Duration: 2, IO: true}, {Duration: 3}, {Duration: 1, IO: true}, {Duration: 2}}}
+	for remaining, want := range []int64{0, 1, 2, 1, 2, 3, 1} {
+		if got := p.cpuBurstLeft(int64(remaining)); got != want {
+			t.Errorf("cpuBurstLeft(%d) = %d, want %d", remaining, got, want)
+		}
+	}
+	if got := (Process{BurstDuration: 6}).cpuBurstLeft(4); got != 4 {
+		t.Errorf("cpuBurstLeft(4) without bursts = %d, want 4", got)
+	}
+}
+
+func Test_readProcessesBursts(t *testing.T) {
+	t.Parallel()
+	processes, err := loadProcesses(strings.NewReader("1,6,0,1,0,1,0,\"4,io:3,2\"\n2,3,1,2\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Burst{{Duration: 4}, {Duration: 3, IO: true}, {Duration: 2}}
+	if !reflect.DeepEqual(processes[0].Bursts, want) || processes[1].Bursts != nil {
+		t.Errorf("loadProcesses() bursts = %v and %v, want %v and none", processes[0].Bursts, processes[1].Bursts, want)
+	}
+
+	var b strings.Builder
+	if err := writeProcesses(&b, processes); err != nil {
+		t.Fatal(err)
+	}
+	if want := "1,6,0,1,0,1,0,\"4,io:3,2\"\n2,3,1,2,0,1,0,\n"; b.String() != want {
+		t.Errorf("writeProcesses() = %q, want %q", b.String(), want)
+	}
+
+	_, err = loadProcesses(strings.NewReader("1,5,0,1,0,1,0,\"4,io:3,2\"\n"))
+	if want := "row 1, column 8: CPU bursts \"4,io:3,2\" add up to 6, but the burst duration is 5"; err == nil || !strings.HasSuffix(err.Error(), want) {
+		t.Errorf("loadProcesses() error = %v, want it to end in %q", err, want)
+	}
+}
+
+func TestSchedulersIOBursts(t *testing.T) {
+	t.Parallel()
+	// P1 blocks on I/O from t=4 to t=7, leaving the CPU to P2, and rejoins the ready queue after
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 6, Bursts: []Burst{{Duration: 4}, {Duration: 3, IO: true}, {Duration: 2}}},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3},
+	}
+	// unless preempted first, P1 leaves the CPU to P2 for its I/O from t=4 to t=7 and rejoins after
+	blocking := []TimeSlice{{PID: 1, Start: 0, Stop: 4}, {PID: 2, Start: 4, Stop: 7}, {PID: 1, Start: 7, Stop: 9}}
+	// under the two-tick quanta and slices P1 only reaches its I/O at t=6, when P2 has one tick left
+	sliced := []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 4}, {PID: 1, Start: 4, Stop: 6}, {PID: 2, Start: 6, Stop: 7}, {PID: 1, Start: 9, Stop: 11}}
+	// P2 has less left than P1 on arrival, so P1 only does its I/O from t=7
+	remaining := []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 4}, {PID: 1, Start: 4, Stop: 7}, {PID: 1, Start: 10, Stop: 12}}
+	wantGantt := map[string][]TimeSlice{
+		"fcfs": blocking, "sjf": blocking, "hrrn": blocking, "lrtf": blocking, "priority": blocking, "arrival-priority": blocking, "edf": blocking,
+		"srtf": remaining, "sjf-priority": remaining,
+		"rr": sliced, "mlfq": sliced, "cfs": sliced,
+	}
+	for _, algo := range algorithms {
+		if !algo.io {
+			continue
+		}
+		algo := algo
+		t.Run(algo.name, func(t *testing.T) {
+			t.Parallel()
+			res := algo.schedule.Schedule(context.Background(), io.Discard, algo.title, processes, SchedulerOptions{})
+			want, ok := wantGantt[algo.name]
+			if !ok {
+				t.Fatalf("no expected Gantt for %s", algo.name)
+			}
+			if !reflect.DeepEqual(res.Gantt, want) {
+				t.Errorf("Gantt = %v, want %v", res.Gantt, want)
+			}
+			// the I/O starts where P1's fourth tick of CPU ends
+			var ran int64
+			for _, slice := range res.Gantt {
+				if slice.PID == 1 && ran < 4 {
+					ran += slice.Stop - slice.Start
+					if ran == 4 && !reflect.DeepEqual(res.Data[0].IO, []TimeSlice{{PID: 1, Start: slice.Stop, Stop: slice.Stop + 3}}) {
+						t.Errorf("P1 IO = %v, want 3 ticks from t=%d", res.Data[0].IO, slice.Stop)
+					}
+				}
+			}
+			// blocked time is turnaround but not waiting
+			for i, proc := range res.Data {
+				if want := proc.TotalWait + processes[i].BurstDuration + proc.ioTicks(); proc.TAround != want || proc.ExitTime-processes[i].ArrivalTime != want {
+					t.Errorf("P%d turnaround = %d, exit = %d, want wait + burst + I/O = %d", i+1, proc.TAround, proc.ExitTime, want)
+				}
+			}
+		})
+	}
+
+	// a hand-built order doesn't simulate the I/O, and says so
+	if _, res := GanttFromOrder(processes, []int64{1, 2}); len(res.Notes) == 0 || res.Notes[len(res.Notes)-1] != ioIgnoredNote {
+		t.Errorf("GanttFromOrder() Notes = %q, want them to end in %q", res.Notes, ioIgnoredNote)
+	}
+
+	// shortest-job-first ranks P1 by its first CPU burst, not its whole CPU time
+	res := NonPreemptiveSJFSchedule(context.Background(), io.Discard, "Shortest-job-first", []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 6, Bursts: []Burst{{Duration: 1}, {Duration: 4, IO: true}, {Duration: 5}}},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 3},
+	}, SchedulerOptions{})
+	if want := []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 4}, {PID: 1, Start: 5, Stop: 10}}; !reflect.DeepEqual(res.Gantt, want) {
+		t.Errorf("Gantt = %v, want %v", res.Gantt, want)
+	}
+
+	// a process cut off by the horizon during its I/O is left blocked
+	res = NonPreemptiveSJFSchedule(context.Background(), io.Discard, "Shortest-job-first", processes[:1], SchedulerOptions{Horizon: 5})
+	if res.Data[0].State != StateBlocked {
+		t.Errorf("State = %v, want %v", res.Data[0].State, StateBlocked)
+	}
+	if want := []string{"P1 unfinished with 2 burst remaining, blocked on I/O"}; !reflect.DeepEqual(res.Notes, want) {
+		t.Errorf("Notes = %q, want %q", res.Notes, want)
+	}
+}
+
+func TestRRScheduleIO(t *testing.T) {
+	t.Parallel()
+	// P1 gives up its quantum after a tick for its I/O, and rejoins the round when it is done
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, Bursts: []Burst{{Duration: 1}, {Duration: 2, IO: true}, {Duration: 1}}},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3},
+	}
+	res := RRSchedule(context.Background(), io.Discard, "Round-robin", processes, SchedulerOptions{})
+	if want := []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 3}, {PID: 1, Start: 3, Stop: 4}, {PID: 2, Start: 4, Stop: 5}}; !reflect.DeepEqual(res.Gantt, want) {
+		t.Errorf("Gantt = %v, want %v", res.Gantt, want)
+	}
+	if want := []TimeSlice{{PID: 1, Start: 1, Stop: 3}}; !reflect.DeepEqual(res.Data[0].IO, want) {
+		t.Errorf("P1 IO = %v, want %v", res.Data[0].IO, want)
+	}
+	if res.Data[0].TotalWait != 0 || res.Data[0].TAround != 4 || res.Data[1].TotalWait != 1 {
+		t.Errorf("Data = %+v, want P1 to wait 0 with turnaround 4 and P2 to wait 1", res.Data)
+	}
+
+	// with every process blocked the CPU idles, and blocking loses no work to the penalty
+	res = RRSchedule(context.Background(), io.Discard, "Round-robin", processes[:1], SchedulerOptions{PreemptPenalty: 1})
+	if want := []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 1, Start: 3, Stop: 4}}; !reflect.DeepEqual(res.Gantt, want) || res.IdleTicks != 2 || res.LostWork != 0 {
+		t.Errorf("Gantt = %v with %d idle ticks and %d lost, want %v, 2 and 0", res.Gantt, res.IdleTicks, res.LostWork, want)
+	}
+}
+
+func Test_eventLogIO(t *testing.T) {
+	t.Parallel()
+	gantt := []TimeSlice{{PID: 1, Start: 0, Stop: 4}, {PID: 2, Start: 4, Stop: 7}, {PID: 1, Start: 7, Stop: 9}}
+	pd := []ProcessData{{ExitTime: 9, IO: []TimeSlice{{PID: 1, Start: 4, Stop: 7}}}, {ExitTime: 7}}
+	want := []string{
+		"t=0 P1 dispatched",
+		"t=4 P1 blocked on I/O until t=7",
+		"t=4 P2 dispatched",
+		"t=7 P2 completed",
+		"t=7 P1 dispatched",
+		"t=9 P1 completed",
+	}
+	if got := eventLog(gantt, pd); !reflect.DeepEqual(got, want) {
+		t.Errorf("eventLog() = %v, want %v", got, want)
+	}
+}
+
+func Test_ioNotes(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: 1, BurstDuration: 3, Bursts: []Burst{{Duration: 1}, {Duration: 2, IO: true}, {Duration: 2}}}}
+	algos := []algorithm{{title: "Custom order"}, {title: "Shortest-job-first", io: true}}
+	want := []string{"only fcfs, sjf, hrrn, srtf, lrtf, sjf-priority, priority, arrival-priority, edf, rr, mlfq and cfs simulate I/O bursts, so the other algorithms (Custom order) run each process's CPU bursts back to back"}
+	if got := ioNotes(processes, algos); !reflect.DeepEqual(got, want) {
+		t.Errorf("ioNotes() = %q, want %q", got, want)
+	}
+	if got := ioNotes(processes, algos[1:]); got != nil {
+		t.Errorf("ioNotes() with only I/O schedulers = %q, want none", got)
+	}
+	if got := ioNotes([]Process{{ProcessID: 1, BurstDuration: 3}}, algos); got != nil {
+		t.Errorf("ioNotes() without I/O = %q, want none", got)
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"io"
+)
+
+// cfsSlice is how many ticks CFSSchedule runs the chosen process before choosing again.
+const cfsSlice = 2
+
+// cfsQueue is the run queue of CFSSchedule: the indices of the runnable processes that aren't
+// running, as a heap ordered by virtual runtime, ties broken by PID.
+type cfsQueue struct {
+	indices   []int
+	vruntime  []float64 // of every process, by index
+	processes []Process
+}
+
+func (q *cfsQueue) Len() int { return len(q.indices) }
+
+func (q *cfsQueue) Less(i, j int) bool {
+	a, b := q.indices[i], q.indices[j]
+	if q.vruntime[a] != q.vruntime[b] {
+		return q.vruntime[a] < q.vruntime[b]
+	}
+	return q.processes[a].ProcessID < q.processes[b].ProcessID
+}
+
+func (q *cfsQueue) Swap(i, j int) { q.indices[i], q.indices[j] = q.indices[j], q.indices[i] }
+
+func (q *cfsQueue) Push(x any) { q.indices = append(q.indices, x.(int)) }
+
+func (q *cfsQueue) Pop() any {
+	last := q.indices[len(q.indices)-1]
+	q.indices = q.indices[:len(q.indices)-1]
+	return last
+}
+
+// CFSSchedule outputs a schedule in the style of Linux's Completely Fair Scheduler. Every process
+// accumulates virtual runtime as it runs, each tick adding 1/weight (see Process.Weight), and
+// every cfsSlice ticks, or when the running process exits, the runnable process with the least
+// virtual runtime runs next, ties broken by PID. A newly released process starts at the least
+// virtual runtime of the processes already runnable, so it neither starves them nor waits for
+// them to catch up with the history it missed.
+//
+// The table gets a Vruntime column with each process's final virtual runtime, and a note reports
+// how fair the schedule was: Jain's index (see jainIndex) of each completed process's CPU share,
+// burst over turnaround, divided by its weight.
+func CFSSchedule(ctx context.Context, w io.Writer, title string, processes []Process, opts SchedulerOptions) ScheduleResult {
+	var (
+		queue      = &cfsQueue{vruntime: make([]float64, len(processes)), processes: processes}
+		queued     = make([]bool, len(processes)) // in the run queue
+		minRuntime float64                        // the least vruntime of the runnable processes, never decreasing
+	)
+
+	s := runTicks(ctx, title, processes, opts, tickPolicy{
+		next: func(s *tickSim) int {
+			current := s.current
+			least, runnable := 0.0, false // the least vruntime of the running and queued processes
+			if current >= 0 {
+				least, runnable = queue.vruntime[current], true
+			}
+			if queue.Len() > 0 && (!runnable || queue.vruntime[queue.indices[0]] < least) {
+				least, runnable = queue.vruntime[queue.indices[0]], true
+			}
+			if runnable && least > minRuntime {
+				minRuntime = least
+			}
+			for i := range processes {
+				if i != current && !queued[i] && s.ready(i) { // released, or back from I/O
+					if queue.vruntime[i] < minRuntime {
+						queue.vruntime[i] = minRuntime
+					}
+					queued[i] = true
+					heap.Push(queue, i)
+				}
+			}
+
+			if current >= 0 && (s.dispatched == 0 || s.dispatched%cfsSlice != 0) {
+				return current
+			}
+			// choose again after every slice
+			if current >= 0 {
+				queued[current] = true
+				heap.Push(queue, current)
+			}
+			next := -1
+			if queue.Len() > 0 {
+				next = heap.Pop(queue).(int)
+				queued[next] = false
+			}
+			return next
+		},
+		ran: func(s *tickSim) {
+			queue.vruntime[s.current] += 1 / float64(processes[s.current].weight())
+		},
+	})
+
+	res := s.result(title)
+	if res.Rows != nil {
+		res.Header = append(res.Header, "Vruntime")
+		for i := range res.Rows {
+			res.Rows[i] = append(res.Rows[i], fmt.Sprintf("%.2f", queue.vruntime[i]))
+		}
+	}
+	if fairness, ok := weightedFairness(s.processes, s.pd); ok {
+		res.Notes = append(res.Notes, fmt.Sprintf("Fairness: Jain's index of CPU share per weight %.2f (1 is perfectly fair)", fairness))
+	}
+	outputResult(w, opts, res)
+	return res
+}
+
+// weightedFairness is Jain's index of each completed process's CPU share, burst over turnaround,
+// divided by its weight: 1 when every process got the CPU in proportion to its weight while it
+// was in the system. It reports false when no process completed.
+func weightedFairness(processes []Process, pd []ProcessData) (float64, bool) {
+	var shares []float64
+	for i, proc := range pd {
+		if proc.ExitTime == 0 || proc.TAround <= 0 {
+			continue
+		}
+		share := float64(processes[i].BurstDuration) / float64(proc.TAround)
+		shares = append(shares, share/float64(processes[i].weight()))
+	}
+	return jainIndex(shares), len(shares) > 0
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCFSSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 6, Weight: 1},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 6, Weight: 2},
+		{ProcessID: 3, ArrivalTime: 3, BurstDuration: 2, Weight: 1},
+	}
+	res := CFSSchedule(context.Background(), io.Discard, "Completely fair", processes, SchedulerOptions{ShowWeight: true})
+	// P2's double weight makes its vruntime grow half as fast, and P3 starts at P2's vruntime of
+	// 0.5 rather than at 0, so it runs once then waits its turn like the others
+	wantGantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 2},
+		{PID: 2, Start: 2, Stop: 4},
+		{PID: 3, Start: 4, Stop: 6},
+		{PID: 2, Start: 6, Stop: 8},
+		{PID: 1, Start: 8, Stop: 10},
+		{PID: 2, Start: 10, Stop: 12},
+		{PID: 1, Start: 12, Stop: 14},
+	}
+	if !reflect.DeepEqual(res.Gantt, wantGantt) {
+		t.Errorf("Gantt = %v, want %v", res.Gantt, wantGantt)
+	}
+
+	if got := res.Header[len(res.Header)-1]; got != "Vruntime" {
+		t.Errorf("last column = %q, want %q", got, "Vruntime")
+	}
+	var vruntimes []string
+	for _, row := range res.Rows {
+		vruntimes = append(vruntimes, row[len(row)-1])
+	}
+	if want := []string{"6.00", "3.00", "2.50"}; !reflect.DeepEqual(vruntimes, want) {
+		t.Errorf("Vruntime column = %v, want %v", vruntimes, want)
+	}
+	if want := "Fairness: Jain's index of CPU share per weight 0.87"; len(res.Notes) != 1 || !strings.HasPrefix(res.Notes[0], want) {
+		t.Errorf("Notes = %q, want one starting with %q", res.Notes, want)
+	}
+}
+
+func Test_weightedFairness(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{BurstDuration: 1, Weight: 1}, {BurstDuration: 4, Weight: 2}, {BurstDuration: 1}}
+	// both completed processes got half the CPU per unit of weight
+	pd := []ProcessData{{TAround: 2, ExitTime: 4}, {TAround: 4, ExitTime: 4}, {Remaining: 1}}
+	if got, ok := weightedFairness(processes, pd); !ok || got != 1 {
+		t.Errorf("weightedFairness() = %v, %v, want 1, true", got, ok)
+	}
+	if _, ok := weightedFairness(processes, make([]ProcessData, len(processes))); ok {
+		t.Error("weightedFairness() without completions reports ok")
+	}
+}
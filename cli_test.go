@@ -0,0 +1,108 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// cliArgsEnv holds the arguments TestHelperCLI runs main with, separated by newlines.
+const cliArgsEnv = "SCHEDULER_TEST_CLI_ARGS"
+
+// TestHelperCLI isn't a real test: run as a subprocess by runCLI, it runs main with the
+// arguments in cliArgsEnv, so the whole flow including os.Exit is exercised.
+func TestHelperCLI(t *testing.T) {
+	args, ok := os.LookupEnv(cliArgsEnv)
+	if !ok {
+		t.Skip("only runs as a subprocess of the CLI tests")
+	}
+	os.Args = append([]string{"scheduler"}, strings.Split(args, "\n")...)
+	main()
+	os.Exit(0)
+}
+
+// runCLI runs the scheduler with args in a subprocess and returns its combined output and exit code.
+func runCLI(t *testing.T, args ...string) (string, int) {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], "-test.run=^TestHelperCLI$")
+	cmd.Env = append(os.Environ(), cliArgsEnv+"="+strings.Join(args, "\n"))
+	out, err := cmd.CombinedOutput()
+	var exitErr *exec.ExitError
+	switch {
+	case errors.As(err, &exitErr):
+		return string(out), exitErr.ExitCode()
+	case err != nil:
+		t.Fatal(err)
+	}
+	return string(out), 0
+}
+
+func TestCLIFixtures(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		fixture  string
+		wantCode int
+		wantErr  string // part of the output of a failing run
+	}{
+		{fixture: "empty.csv", wantCode: 1, wantErr: "no processes found in input"},
+		{fixture: "single.csv"},
+		{fixture: "late_arrivals.csv"},
+		{fixture: "ties.csv"},
+		{fixture: "nonsequential_pids.csv"},
+		{fixture: "missing_priority.csv"},
+		{fixture: "comments.csv"},
+		{fixture: "missing_burst.csv", wantCode: 1, wantErr: "row 2 must have at least a process ID and a burst duration"},
+		{fixture: "zero_burst.csv", wantCode: 1, wantErr: "process 2: burst duration must be positive, got 0"},
+	}
+	fixtures, err := filepath.Glob(filepath.Join("testdata", "cli", "*.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fixtures) != len(tests) {
+		t.Errorf("testdata/cli has %d fixtures, the test covers %d", len(fixtures), len(tests))
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.fixture, func(t *testing.T) {
+			t.Parallel()
+			out, code := runCLI(t, filepath.Join("testdata", "cli", tt.fixture))
+			if code != tt.wantCode {
+				t.Fatalf("exit code = %d, want %d; output:\n%s", code, tt.wantCode, out)
+			}
+			if strings.Contains(out, "panic") {
+				t.Errorf("output has a panic:\n%s", out)
+			}
+			if tt.wantCode != 0 {
+				if !strings.Contains(out, tt.wantErr) {
+					t.Errorf("output is missing %q:\n%s", tt.wantErr, out)
+				}
+				return
+			}
+			for _, algo := range algorithms {
+				if !strings.Contains(out, algo.title) {
+					t.Errorf("output is missing the %s schedule:\n%s", algo.title, out)
+				}
+			}
+			if n := strings.Count(out, "Schedule table"); n != len(algorithms) {
+				t.Errorf("output has %d schedule tables, want %d", n, len(algorithms))
+			}
+		})
+	}
+}
+
+func TestCLIStrictHeader(t *testing.T) {
+	t.Parallel()
+	// the fixture's header is on line 2, after a comment
+	fixture := filepath.Join("testdata", "cli", "comments.csv")
+	out, code := runCLI(t, "validate", "-strict", fixture)
+	if code != 1 || !strings.Contains(out, "row 2: header row skipped") {
+		t.Errorf("validate -strict exit code = %d with output:\n%s\nwant 1 and the skipped header", code, out)
+	}
+	if out, code := runCLI(t, "validate", fixture); code != 0 || !strings.Contains(out, "row 2: header row skipped") {
+		t.Errorf("validate exit code = %d with output:\n%s\nwant 0 and a warning about the skipped header", code, out)
+	}
+}
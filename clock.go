@@ -0,0 +1,33 @@
+package main
+
+// Clock is the simulated time source of the tick-based schedulers. A simulation reads the start
+// time from Now and calls Advance once per tick; the schedulers never move time on their own, so
+// a Clock observes every tick and can drive tests of behaviour at given times.
+type Clock interface {
+	// Now returns the current simulated time.
+	Now() int64
+	// Advance moves the clock forward one tick and returns the new time.
+	Advance() int64
+}
+
+// TickClock is the default Clock: a counter starting at t=0.
+type TickClock struct {
+	now int64
+}
+
+func (c *TickClock) Now() int64 {
+	return c.now
+}
+
+func (c *TickClock) Advance() int64 {
+	c.now++
+	return c.now
+}
+
+// clock returns a fresh clock for one simulation.
+func (o SchedulerOptions) clock() Clock {
+	if o.Clock == nil {
+		return &TickClock{}
+	}
+	return o.Clock()
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"io"
+	"testing"
+)
+
+// recordingClock is a test Clock that records every time it advances to.
+type recordingClock struct {
+	TickClock
+	ticks []int64
+}
+
+func (c *recordingClock) Advance() int64 {
+	now := c.TickClock.Advance()
+	c.ticks = append(c.ticks, now)
+	return now
+}
+
+func TestSchedulersAdvanceClock(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+	}
+	for _, tt := range testSchedulers {
+		tt := tt
+		if tt.name == "FCFS" {
+			continue // computes the schedule without ticking
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			clock := &recordingClock{}
+			opts := SchedulerOptions{Clock: func() Clock { return clock }}
+			res := tt.schedule(context.Background(), io.Discard, tt.name, processes, opts)
+
+			for i, tick := range clock.ticks {
+				if tick != int64(i+1) {
+					t.Fatalf("tick %d reached t=%d, want t=%d", i, tick, i+1)
+				}
+			}
+			for i, proc := range res.Data {
+				if len(clock.ticks) == 0 || proc.ExitTime > clock.ticks[len(clock.ticks)-1] {
+					t.Errorf("P%d exited at t=%d, after the clock stopped at %v", processes[i].ProcessID, proc.ExitTime, clock.ticks)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// Grouping splits the rows of a schedule table into cohorts by the value of one integer column.
+type Grouping struct {
+	// Column is the header of the column to group by, e.g. "Arrival"; empty disables grouping.
+	Column string
+	// Bucket, when positive, puts the values in [k·Bucket, (k+1)·Bucket) into the same cohort
+	// instead of grouping by exact value.
+	Bucket int64
+}
+
+// groupColumns maps the names -group-by accepts to the schedule table column they group by.
+var groupColumns = map[string]string{"arrival": "Arrival"}
+
+// parseGroupBy parses a -group-by spec: a column name such as "arrival", optionally followed by
+// a bucket width, e.g. "arrival:5".
+func parseGroupBy(spec string) (Grouping, error) {
+	if spec == "" {
+		return Grouping{}, nil
+	}
+	name, width, bucketed := strings.Cut(spec, ":")
+	column, ok := groupColumns[name]
+	if !ok {
+		return Grouping{}, fmt.Errorf("%w: can't group by %q, must be arrival", ErrInvalidArgs, name)
+	}
+	g := Grouping{Column: column}
+	if bucketed {
+		bucket, err := strconv.ParseInt(width, 10, 64)
+		if err != nil || bucket < 1 {
+			return Grouping{}, fmt.Errorf("%w: group-by bucket %q must be a positive integer", ErrInvalidArgs, width)
+		}
+		g.Bucket = bucket
+	}
+	return g, nil
+}
+
+// Cohort is the processes of a schedule sharing a value, or a bucket of values, of the grouped column.
+type Cohort struct {
+	// From and To are the inclusive range of values in the cohort; they are equal without buckets.
+	From, To  int64
+	Processes int
+	Completed int
+	// AvgWait and AvgTurnaround average over the Completed processes of the cohort.
+	AvgWait       float64
+	AvgTurnaround float64
+}
+
+// cohorts groups the rows of a result by g, in increasing order of value. Every row counts
+// towards its cohort, but only the processes that exited count towards the averages, as in the
+// schedule's own averages. It returns nil if the result has no such column.
+func cohorts(res ScheduleResult, g Grouping) []Cohort {
+	column := -1
+	for i, name := range res.Header {
+		if name == g.Column {
+			column = i
+		}
+	}
+	if column < 0 {
+		return nil
+	}
+
+	byKey := make(map[int64]*Cohort)
+	for i, row := range res.Rows {
+		value, err := strconv.ParseInt(row[column], 10, 64)
+		if err != nil {
+			continue
+		}
+		from, to := value, value
+		if g.Bucket > 0 {
+			from = value / g.Bucket * g.Bucket
+			to = from + g.Bucket - 1
+		}
+		c, ok := byKey[from]
+		if !ok {
+			c = &Cohort{From: from, To: to}
+			byKey[from] = c
+		}
+		c.Processes++
+		if proc := res.Data[i]; proc.ExitTime != 0 {
+			c.Completed++
+			c.AvgWait += float64(proc.TotalWait)
+			c.AvgTurnaround += float64(proc.TAround)
+		}
+	}
+
+	groups := make([]Cohort, 0, len(byKey))
+	for _, c := range byKey {
+		if c.Completed > 0 {
+			c.AvgWait /= float64(c.Completed)
+			c.AvgTurnaround /= float64(c.Completed)
+		}
+		groups = append(groups, *c)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].From < groups[j].From })
+	return groups
+}
+
+// outputCohorts renders the cohorts of a result when opts.GroupBy asks for them, one row per cohort.
+func outputCohorts(w io.Writer, opts SchedulerOptions, res ScheduleResult) {
+	if opts.GroupBy.Column == "" {
+		return
+	}
+	header := []string{opts.GroupBy.Column, "Processes", "Completed", "Avg wait", "Avg turnaround"}
+	var rows [][]string
+	for _, c := range cohorts(res, opts.GroupBy) {
+		label := fmt.Sprint(c.From)
+		if c.To != c.From {
+			label = fmt.Sprintf("%d-%d", c.From, c.To)
+		}
+		wait, turnaround := "-", "-"
+		if c.Completed > 0 {
+			wait, turnaround = fmt.Sprintf("%.2f", c.AvgWait), fmt.Sprintf("%.2f", c.AvgTurnaround)
+		}
+		rows = append(rows, []string{label, fmt.Sprint(c.Processes), fmt.Sprint(c.Completed), wait, turnaround})
+	}
+
+	_, _ = fmt.Fprintf(w, "Cohorts by %s\n", strings.ToLower(opts.GroupBy.Column))
+	if opts.Format == "plain" {
+		writePlainTable(w, header, rows)
+		return
+	}
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(header)
+	table.AppendBulk(rows)
+	table.Render()
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func Test_parseGroupBy(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		spec    string
+		want    Grouping
+		wantErr bool
+	}{
+		{spec: "", want: Grouping{}},
+		{spec: "arrival", want: Grouping{Column: "Arrival"}},
+		{spec: "arrival:5", want: Grouping{Column: "Arrival", Bucket: 5}},
+		{spec: "arrival:0", wantErr: true},
+		{spec: "arrival:x", wantErr: true},
+		{spec: "burst", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseGroupBy(tt.spec)
+		if (err != nil) != tt.wantErr || (err != nil && !errors.Is(err, ErrInvalidArgs)) {
+			t.Errorf("parseGroupBy(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("parseGroupBy(%q) = %v, want %v", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func Test_cohorts(t *testing.T) {
+	t.Parallel()
+	// a batch of three at t=0 and two stragglers
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2},
+		{ProcessID: 3, ArrivalTime: 0, BurstDuration: 2},
+		{ProcessID: 4, ArrivalTime: 3, BurstDuration: 1},
+		{ProcessID: 5, ArrivalTime: 9, BurstDuration: 1},
+	}
+	res := FCFSSchedule(context.Background(), io.Discard, "First-come, first-serve", processes, SchedulerOptions{})
+	// P1-P3 wait 0, 4 and 6, P4 waits 5 and P5 arrives after the others exit
+	want := []Cohort{
+		{From: 0, To: 0, Processes: 3, Completed: 3, AvgWait: 10.0 / 3, AvgTurnaround: 18.0 / 3},
+		{From: 3, To: 3, Processes: 1, Completed: 1, AvgWait: 5, AvgTurnaround: 6},
+		{From: 9, To: 9, Processes: 1, Completed: 1, AvgWait: 0, AvgTurnaround: 1},
+	}
+	if got := cohorts(res, Grouping{Column: "Arrival"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("cohorts() = %v, want %v", got, want)
+	}
+
+	wantBuckets := []Cohort{
+		{From: 0, To: 4, Processes: 4, Completed: 4, AvgWait: 15.0 / 4, AvgTurnaround: 24.0 / 4},
+		{From: 5, To: 9, Processes: 1, Completed: 1, AvgWait: 0, AvgTurnaround: 1},
+	}
+	if got := cohorts(res, Grouping{Column: "Arrival", Bucket: 5}); !reflect.DeepEqual(got, wantBuckets) {
+		t.Errorf("cohorts() in buckets of 5 = %v, want %v", got, wantBuckets)
+	}
+
+	var w bytes.Buffer
+	outputCohorts(&w, SchedulerOptions{Format: "plain", GroupBy: Grouping{Column: "Arrival", Bucket: 5}}, res)
+	wantOut := "Cohorts by arrival\n" +
+		"Arrival  Processes  Completed  Avg wait  Avg turnaround\n" +
+		"    0-4          4          4      3.75            6.00\n" +
+		"    5-9          1          1      0.00            1.00\n"
+	if w.String() != wantOut {
+		t.Errorf("outputCohorts() = %q, want %q", w.String(), wantOut)
+	}
+}
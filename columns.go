@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// tableColumnNames are every column a schedule table can have, in the order scheduleHeader and
+// the schedulers that add their own columns put them.
+var tableColumnNames = []string{
+	"ID", "Priority", "Weight", "Burst", "Arrival", "Release", "Wait", "Response", "Turnaround", "Norm.TA", "Exit",
+	"Deadline", "Missed", "Laxity", "Queue", "Vruntime",
+}
+
+// parseColumns parses a -columns spec: comma-separated column names, matched case-insensitively,
+// in the order the table should show them, e.g. "id,burst,response". An empty spec keeps every column.
+func parseColumns(spec string) ([]string, error) {
+	if spec == "" {
+		return nil, nil
+	}
+	var columns []string
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		column := ""
+		for _, known := range tableColumnNames {
+			if strings.EqualFold(name, known) {
+				column = known
+			}
+		}
+		if column == "" {
+			return nil, fmt.Errorf("%w: unknown column %q, must be one of %s", ErrInvalidArgs, name, strings.Join(tableColumnNames, ", "))
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+// wantsColumn reports whether opts.Columns asks for any of the columns, which turns on the
+// optional ones that are otherwise only shown when the processes need them.
+func (opts SchedulerOptions) wantsColumn(columns ...string) bool {
+	for _, want := range opts.Columns {
+		for _, column := range columns {
+			if want == column {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// selectColumns returns res with its table cut down to the columns asked for, in their order.
+// Columns this schedule doesn't have, such as Queue outside MLFQ, are left out; no columns keeps
+// the table as is. The rest of the result is shared with res.
+func selectColumns(res ScheduleResult, columns []string) ScheduleResult {
+	if len(columns) == 0 || res.Header == nil {
+		return res
+	}
+	var picked []int
+	for _, column := range columns {
+		for i, name := range res.Header {
+			if name == column {
+				picked = append(picked, i)
+			}
+		}
+	}
+	shown := res
+	shown.Header = make([]string, len(picked))
+	for k, i := range picked {
+		shown.Header[k] = res.Header[i]
+	}
+	shown.Rows = make([][]string, len(res.Rows))
+	for j, row := range res.Rows {
+		shown.Rows[j] = make([]string, len(picked))
+		for k, i := range picked {
+			shown.Rows[j][k] = row[i]
+		}
+	}
+	return shown
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_parseColumns(t *testing.T) {
+	t.Parallel()
+	got, err := parseColumns("id, BURST,response,deadline")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"ID", "Burst", "Response", "Deadline"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("parseColumns() = %v, want %v", got, want)
+	}
+	if got, err := parseColumns(""); got != nil || err != nil {
+		t.Errorf("parseColumns(\"\") = %v, %v, want every column", got, err)
+	}
+	if _, err := parseColumns("id,colour"); !errors.Is(err, ErrInvalidArgs) || !strings.Contains(err.Error(), `"colour"`) {
+		t.Errorf("parseColumns() error = %v, want %v naming the unknown column", err, ErrInvalidArgs)
+	}
+}
+
+func Test_selectColumns(t *testing.T) {
+	t.Parallel()
+	res := ScheduleResult{
+		Header: []string{"ID", "Priority", "Burst", "Wait"},
+		Rows:   [][]string{{"1", "2", "5", "0"}, {"2", "1", "3", "5"}},
+	}
+	shown := selectColumns(res, []string{"Wait", "ID", "Queue"})
+	if want := []string{"Wait", "ID"}; !reflect.DeepEqual(shown.Header, want) {
+		t.Errorf("Header = %v, want %v without the missing Queue", shown.Header, want)
+	}
+	if want := [][]string{{"0", "1"}, {"5", "2"}}; !reflect.DeepEqual(shown.Rows, want) {
+		t.Errorf("Rows = %v, want %v", shown.Rows, want)
+	}
+	if len(res.Header) != 4 || res.Rows[0][1] != "2" {
+		t.Errorf("selectColumns() changed the result it was given: %v", res)
+	}
+
+	// naming an optional column shows it although no process needs it
+	cols := scheduleColumns([]Process{{ProcessID: 1, BurstDuration: 2}}, SchedulerOptions{Columns: []string{"ID", "Deadline"}})
+	if !cols.deadline || cols.laxity || cols.weight {
+		t.Errorf("scheduleColumns() = %+v, want only the deadline columns", cols)
+	}
+}
@@ -0,0 +1,766 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// command is a CLI subcommand; its flags are parsed from the arguments after its name.
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) error
+}
+
+// commands lists the subcommands in the order the help text shows them. The first one runs when
+// no subcommand is named.
+var commands []command
+
+func init() {
+	// assigned in init because runHelp refers back to commands
+	commands = []command{
+		{name: "schedule", summary: "run the schedulers and render each schedule (default)", run: runSchedule},
+		{name: "compare", summary: "summarise every scheduler's metrics in one table", run: runCompare},
+		{name: "generate", summary: "write a random workload as CSV", run: runGenerate},
+		{name: "validate", summary: "check a process file without scheduling it", run: runValidate},
+		{name: "help", summary: "list the subcommands", run: runHelp},
+	}
+}
+
+// splitCommand picks the subcommand named by the first argument, falling back to the default
+// command with every argument when the first one is not a subcommand name.
+func splitCommand(args []string) (command, []string) {
+	if len(args) > 0 {
+		for _, cmd := range commands {
+			if cmd.name == args[0] {
+				return cmd, args[1:]
+			}
+		}
+	}
+	return commands[0], args
+}
+
+func runHelp([]string) error {
+	outputCommands(os.Stdout)
+	return nil
+}
+
+func outputCommands(w io.Writer) {
+	_, _ = fmt.Fprintf(w, "Usage: %s [command] [flags] [processes.csv]\n\nCommands:\n", filepath.Base(os.Args[0]))
+	for _, cmd := range commands {
+		_, _ = fmt.Fprintf(w, "  %-10s %s\n", cmd.name, cmd.summary)
+	}
+	_, _ = fmt.Fprintf(w, "\nRun '%s <command> -h' for the flags of a command.\n", filepath.Base(os.Args[0]))
+}
+
+// newFlagSet returns a flag set for a command whose usage names its file argument.
+func newFlagSet(name, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.TextVar(&logLevel, "log-level", new(slog.LevelVar), "minimum level of the diagnostics written to stderr: debug, info, warn or error")
+	fs.Usage = func() {
+		_, _ = fmt.Fprintf(fs.Output(), "Usage: %s %s %s\n", filepath.Base(os.Args[0]), name, usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// envPrefix starts the name of the environment variable that sets each flag's default.
+const envPrefix = "SCHED_"
+
+// flagEnv returns the environment variable for a flag: envPrefix and the flag name in upper case
+// with dashes as underscores, e.g. SCHED_PRIORITY_ORDER for -priority-order.
+func flagEnv(name string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+// parseFlags parses args into fs after setting each flag whose environment variable is set to
+// that variable's value, so a flag on the command line takes precedence over the environment,
+// which takes precedence over the flag's default.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	var err error
+	fs.VisitAll(func(f *flag.Flag) {
+		value, ok := os.LookupEnv(flagEnv(f.Name))
+		if !ok || err != nil {
+			return
+		}
+		if setErr := fs.Set(f.Name, value); setErr != nil {
+			err = fmt.Errorf("%w: %s=%q: %v", ErrInvalidArgs, flagEnv(f.Name), value, setErr)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return fs.Parse(args)
+}
+
+// simulationFlags are the flags shared by the commands that run schedulers.
+type simulationFlags struct {
+	preemptPenalty *float64
+	locks          *string
+	nonPreemptible *string
+	timeout        *time.Duration
+	horizon        *int64
+	priorityOrder  *string
+	aging          *int64
+	backlog        *int
+	quantum        *int64
+	mlfqQuanta     *string
+	algo           *string
+	rrOverhead     *float64
+	maxTicks       *int64
+	generate       *int
+	seed           *int64
+	renumber       *bool
+	trace          *bool
+	delimiter      *string
+	strict         *bool
+}
+
+func addSimulationFlags(fs *flag.FlagSet) *simulationFlags {
+	return &simulationFlags{
+		preemptPenalty: fs.Float64("preempt-penalty", 0, "fraction [0-1] of work since dispatch lost when a process is preempted"),
+		locks:          fs.String("locks", "", "shared resource use as comma-separated pid:at:for entries (see PrioritySchedule)"),
+		nonPreemptible: fs.String("non-preemptible", "", "comma-separated IDs of processes that run to completion once dispatched under the priority and SJF schedulers"),
+		timeout:        fs.Duration("timeout", 0, "abort any single simulation running longer than this (e.g. 10s); 0 disables"),
+		horizon:        fs.Int64("horizon", 0, "stop every simulation at this simulated time, reporting unfinished processes; 0 runs to completion"),
+		priorityOrder:  fs.String("priority-order", "", "comma-separated algorithm=lower|higher entries choosing which priority number runs first, e.g. priority=higher"),
+		aging:          fs.Int64("aging", 0, "improve a waiting process's priority by one step every N ticks it waits under preemptive priority; 0 disables"),
+		backlog:        fs.Int("backlog", 0, "treat the first N processes as already waiting at time 0, whatever their arrival"),
+		quantum:        fs.Int64("quantum", defaultQuantum, "round-robin time slice in ticks, at least 1"),
+		mlfqQuanta:     fs.String("mlfq-quanta", "2,4,8", "comma-separated quanta of the multilevel feedback queues, from the top queue down"),
+		algo:           fs.String("algo", "", "comma-separated algorithms to run, e.g. fcfs,rr; empty runs them all"),
+		rrOverhead:     fs.Float64("rr-overhead", 0, "fraction [0-1) of every round-robin quantum the dispatcher consumes instead of running the process"),
+		maxTicks:       fs.Int64("max-ticks", 0, "fail any simulation still running after this many ticks, e.g. a non-terminating schedule; 0 disables"),
+		generate:       fs.Int("generate", 0, "schedule this many random processes (see GenerateProcesses) instead of reading a processes file; 0 reads one"),
+		seed:           fs.Int64("seed", 1, "random seed for -generate; the same seed always generates the same workload"),
+		renumber:       fs.Bool("renumber", false, "give every row repeating an earlier row's process ID a fresh ID instead of tolerating the duplicate"),
+		trace:          fs.Bool("trace", false, "print every tick of the tick-based schedulers, all but fcfs, to stderr: the running process, the ready queue and any preemption"),
+		delimiter:      addDelimiterFlag(fs),
+		strict:         addStrictFlag(fs),
+	}
+}
+
+// processes returns the processes to simulate: -generate random ones, or else the ones in the
+// processes file args names, or stdin.
+func (f *simulationFlags) processes(args []string) ([]Process, error) {
+	if *f.generate < 0 {
+		return nil, fmt.Errorf("%w: generate must not be negative", ErrInvalidArgs)
+	}
+	if *f.generate == 0 {
+		comma, err := parseDelimiter(*f.delimiter)
+		if err != nil {
+			return nil, err
+		}
+		return loadProcessingFile(args, *f.strict, loadOptions{renumber: *f.renumber, comma: comma})
+	}
+	if len(args) > 0 {
+		return nil, fmt.Errorf("%w: -generate replaces the scheduling file, don't give one", ErrInvalidArgs)
+	}
+	return GenerateProcesses(*f.generate, *f.seed), nil
+}
+
+// options validates the parsed flags, applies the lock spec, non-preemptible processes and backlog
+// to the processes and returns the scheduler options they describe.
+func (f *simulationFlags) options(processes []Process) (SchedulerOptions, error) {
+	if *f.preemptPenalty < 0 || *f.preemptPenalty > 1 {
+		return SchedulerOptions{}, fmt.Errorf("%w: preempt-penalty must be between 0 and 1", ErrInvalidArgs)
+	}
+	if *f.quantum < 1 {
+		return SchedulerOptions{}, fmt.Errorf("%w: quantum must be at least 1, got %d", ErrInvalidArgs, *f.quantum)
+	}
+	if *f.rrOverhead < 0 || *f.rrOverhead >= 1 {
+		return SchedulerOptions{}, fmt.Errorf("%w: rr-overhead must be at least 0 and less than 1", ErrInvalidArgs)
+	}
+	if *f.horizon < 0 {
+		return SchedulerOptions{}, fmt.Errorf("%w: horizon must not be negative", ErrInvalidArgs)
+	}
+	if *f.aging < 0 {
+		return SchedulerOptions{}, fmt.Errorf("%w: aging must not be negative", ErrInvalidArgs)
+	}
+	if *f.maxTicks < 0 {
+		return SchedulerOptions{}, fmt.Errorf("%w: max-ticks must not be negative", ErrInvalidArgs)
+	}
+	mlfqQuanta, err := parseMLFQQuanta(*f.mlfqQuanta)
+	if err != nil {
+		return SchedulerOptions{}, err
+	}
+	if err := parseLocks(*f.locks, processes); err != nil {
+		return SchedulerOptions{}, err
+	}
+	if err := parseNonPreemptible(*f.nonPreemptible, processes); err != nil {
+		return SchedulerOptions{}, err
+	}
+	if err := applyBacklog(processes, *f.backlog); err != nil {
+		return SchedulerOptions{}, err
+	}
+	opts := SchedulerOptions{
+		PreemptPenalty: *f.preemptPenalty,
+		Horizon:        *f.horizon,
+		Aging:          *f.aging,
+		MaxTicks:       *f.maxTicks,
+		Backlog:        *f.backlog,
+		Quantum:        *f.quantum,
+		MLFQQuanta:     mlfqQuanta,
+		RROverhead:     *f.rrOverhead,
+	}
+	if *f.trace {
+		opts.Trace = os.Stderr
+	}
+	return opts, nil
+}
+
+// algorithm is a scheduler the schedule and compare commands run. The name identifies it in
+// flags; priority marks the ones that use Process.Priority and honour PriorityOrder, weighted
+// the ones that use Process.Weight, and the description summarises the policy for -describe.
+type algorithm struct {
+	name        string
+	title       string
+	description string
+	priority    bool
+	weighted    bool
+	io          bool // simulates I/O bursts, see ioSchedulers
+	schedule    Scheduler
+}
+
+// algorithms are the schedulers the schedule and compare commands run, in order.
+var algorithms = []algorithm{
+	{
+		name: "fcfs", title: "First-come, first-serve", io: true, schedule: SchedulerFunc(FCFSSchedule),
+		description: "non-preemptive, runs each process to completion in order of arrival, ties by PID",
+	},
+	{
+		name: "sjf", title: "Shortest-job-first", io: true, schedule: SchedulerFunc(NonPreemptiveSJFSchedule),
+		description: "non-preemptive, runs the released process with the shortest burst to completion, ties by arrival then PID",
+	},
+	{
+		name: "hrrn", title: "Highest-response-ratio-next", io: true, schedule: SchedulerFunc(HRRNSchedule),
+		description: "non-preemptive, runs the released process with the highest (wait + burst) / burst to completion, ties by arrival then PID",
+	},
+	{
+		name: "srtf", title: "Shortest-remaining-time-first", io: true, schedule: SchedulerFunc(SRTFSchedule),
+		description: "preemptive, shortest remaining burst first",
+	},
+	{
+		name: "lrtf", title: "Longest-remaining-time-first", io: true, schedule: SchedulerFunc(LRTFSchedule),
+		description: "preemptive, longest remaining burst first, ties by arrival then PID, which keeps every process waiting",
+	},
+	{
+		name: "sjf-priority", title: "Priority", priority: true, io: true, schedule: SchedulerFunc(SJFPrioritySchedule),
+		description: "preemptive, shortest remaining burst first, ties to the highest priority number",
+	},
+	{
+		name: "priority", title: "Preemptive priority", priority: true, io: true, schedule: SchedulerFunc(PrioritySchedule),
+		description: "preemptive, lowest priority number first, ties by arrival then PID, with priority inheritance on the shared resource",
+	},
+	{
+		name: "arrival-priority", title: "Arrival-preemptive priority", priority: true, io: true, schedule: SchedulerFunc(ArrivalPreemptiveSchedule),
+		description: "first-come, first-serve, preempted only when a process with a lower priority number arrives",
+	},
+	{
+		name: "edf", title: "Earliest-deadline-first", io: true, schedule: SchedulerFunc(EDFSchedule),
+		description: "preemptive, nearest absolute deadline first, ties by arrival then PID, processes without a deadline last",
+	},
+	{
+		name: "rr", title: "Round-robin", io: true, schedule: SchedulerFunc(RRSchedule),
+		description: "preemptive, cycles through the released processes every quantum",
+	},
+	{
+		name: "mlfq", title: "Multilevel feedback queue", io: true, schedule: SchedulerFunc(MLFQSchedule),
+		description: "preemptive, arrivals enter the top queue, a process that uses up its queue's quantum drops a queue, the bottom queue is round-robin",
+	},
+	{
+		name: "cfs", title: "Completely fair", weighted: true, io: true, schedule: SchedulerFunc(CFSSchedule),
+		description: "preemptive, least weighted virtual runtime first, choosing again every 2 ticks",
+	},
+}
+
+// RegisterScheduler adds a scheduler to the ones the schedule and compare commands run, after
+// the built-in ones. The name identifies it in flags and must be unique; the title heads its
+// output and the description summarises the policy for -describe.
+func RegisterScheduler(name, title, description string, s Scheduler) error {
+	if name == "" || s == nil {
+		return fmt.Errorf("%w: a scheduler needs a name and an implementation", ErrInvalidArgs)
+	}
+	for _, algo := range algorithms {
+		if algo.name == name {
+			return fmt.Errorf("%w: a scheduler named %q is already registered", ErrInvalidArgs, name)
+		}
+	}
+	algorithms = append(algorithms, algorithm{name: name, title: title, description: description, schedule: s})
+	return nil
+}
+
+// priorityNotes explains when the priorities of the processes don't matter to the algorithms
+// that run: none of them uses priorities although some process has one, or some of them use
+// priorities although every process's is 0, e.g. because the file has no priority column.
+func priorityNotes(processes []Process, algos []algorithm) []string {
+	var aware []string
+	for _, algo := range algos {
+		if algo.priority {
+			aware = append(aware, algo.title)
+		}
+	}
+	prioritised := false
+	for _, p := range processes {
+		if p.Priority != 0 {
+			prioritised = true
+		}
+	}
+	switch {
+	case len(processes) == 0:
+		return nil
+	case prioritised && len(aware) == 0:
+		return []string{"the priority column is unused: none of the algorithms that run uses priorities"}
+	case !prioritised && len(aware) > 0:
+		return []string{fmt.Sprintf("every priority is 0, so the priority-aware algorithms (%s) treat all processes alike: the priority column is missing or defaulted",
+			strings.Join(aware, ", "))}
+	}
+	return nil
+}
+
+// logPriorityNotes logs priorityNotes as notices, as neither is an error.
+func logPriorityNotes(processes []Process, algos []algorithm) {
+	for _, note := range priorityNotes(processes, algos) {
+		slog.Info(note)
+	}
+}
+
+// logIONotes logs ioNotes as notices.
+func logIONotes(processes []Process, algos []algorithm) {
+	for _, note := range ioNotes(processes, algos) {
+		slog.Info(note)
+	}
+}
+
+// parsePriorityOrders parses a spec of comma-separated algorithm=lower|higher entries into the
+// priority order of each named priority-aware algorithm.
+func parsePriorityOrders(spec string) (map[string]PriorityOrder, error) {
+	orders := make(map[string]PriorityOrder)
+	if spec == "" {
+		return orders, nil
+	}
+	for _, entry := range strings.Split(spec, ",") {
+		name, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: priority order %q must be algorithm=lower|higher", ErrInvalidArgs, entry)
+		}
+		var order PriorityOrder
+		switch value {
+		case "lower":
+			order = LowerFirst
+		case "higher":
+			order = HigherFirst
+		default:
+			return nil, fmt.Errorf("%w: priority order %q: %q is neither lower nor higher", ErrInvalidArgs, entry, value)
+		}
+
+		found := false
+		for _, algo := range algorithms {
+			if algo.name == name {
+				if !algo.priority {
+					return nil, fmt.Errorf("%w: priority order %q: %s ignores priorities", ErrInvalidArgs, entry, name)
+				}
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: priority order %q: unknown algorithm %q", ErrInvalidArgs, entry, name)
+		}
+		orders[name] = order
+	}
+	return orders, nil
+}
+
+// selectAlgorithms returns the algorithms named in a comma-separated spec, in the order they
+// always run, or all of them for an empty spec.
+func selectAlgorithms(spec string) ([]algorithm, error) {
+	if spec == "" {
+		return algorithms, nil
+	}
+	names := make([]string, len(algorithms))
+	for i, algo := range algorithms {
+		names[i] = algo.name
+	}
+	wanted := make(map[string]bool)
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		found := false
+		for _, known := range names {
+			found = found || known == name
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: unknown algorithm %q, must be one of %s", ErrInvalidArgs, name, strings.Join(names, ", "))
+		}
+		wanted[name] = true
+	}
+	var selected []algorithm
+	for _, algo := range algorithms {
+		if wanted[algo.name] {
+			selected = append(selected, algo)
+		}
+	}
+	return selected, nil
+}
+
+// ResultHook receives each scheduler's result as soon as runAlgorithms computes it, for custom
+// post-processing or assertions without touching the renderers. Hooks are called synchronously,
+// in order, on the goroutine running the schedulers, so a hook needs no locking of its own but
+// delays the next simulation until it returns. The result's slices are not copied: a hook that
+// hands them to other goroutines or modifies them must copy them first.
+type ResultHook func(algo string, res ScheduleResult)
+
+// runAlgorithms runs each of algos, each limited to timeout when positive and using its own
+// entry of orders as its PriorityOrder, passes each result to the hooks and returns them all.
+// Ctrl-C stops the running simulation, which still renders its partial schedule, and skips the rest.
+func runAlgorithms(w io.Writer, algos []algorithm, processes []Process, opts SchedulerOptions, orders map[string]PriorityOrder, timeout time.Duration, hooks ...ResultHook) []ScheduleResult {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	for _, algo := range algos {
+		opts.ShowWeight = opts.ShowWeight || algo.weighted
+	}
+	results := make([]ScheduleResult, 0, len(algos))
+	for _, algo := range algos {
+		simCtx, cancel := ctx, context.CancelFunc(func() {})
+		if timeout > 0 {
+			simCtx, cancel = context.WithTimeout(ctx, timeout)
+		}
+		if opts.Describe {
+			outputRemark(w, opts, algo.title+": "+algo.description)
+		}
+		algoOpts := opts
+		algoOpts.PriorityOrder = orders[algo.name]
+		res := algo.schedule.Schedule(simCtx, w, algo.title, processes, algoOpts)
+		cancel()
+		traceResult(ctx, algo.title, res)
+		for _, hook := range hooks {
+			hook(algo.title, res)
+		}
+		results = append(results, res)
+		if errors.Is(res.Err, context.Canceled) { // interrupted, a timeout only aborts that one simulation
+			break
+		}
+	}
+	return results
+}
+
+// traceResult logs every slice of a result's Gantt chart and how far the simulation got at the
+// debug level.
+func traceResult(ctx context.Context, algo string, res ScheduleResult) {
+	logger := slog.Default()
+	if !logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+	for _, slice := range res.Gantt {
+		logger.Debug("ran", "algorithm", algo, "pid", slice.PID, "start", slice.Start, "stop", slice.Stop)
+	}
+	logger.Debug("scheduled", "algorithm", algo, "completed", res.Completed(), "stopped_at", res.StoppedAt)
+}
+
+// outputRemark prints a line between schedules, such as a description, as a comment in DOT and
+// LaTeX output.
+func outputRemark(w io.Writer, opts SchedulerOptions, remark string) {
+	switch opts.Format {
+	case "dot":
+		remark = "// " + remark
+	case "latex":
+		remark = "% " + remark
+	case "json", "csv", "svg":
+		return // they can't carry comments, or not before the document
+	}
+	_, _ = fmt.Fprintln(w, remark)
+}
+
+// loadProcessingFile reads the processes from the single file argument of a command, or from
+// stdin without one, failing if there are none or, in strict mode, if loading them tolerated
+// any anomaly. opts choose how the file is read (see loadOptions).
+func loadProcessingFile(args []string, strict bool, opts loadOptions) ([]Process, error) {
+	f, closeFile, err := openProcessingFile(append([]string{os.Args[0]}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer closeFile()
+
+	processes, anomalies, err := readProcessesWith(f, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(processes) == 0 { // nothing to schedule, and every average would divide by zero
+		return nil, fmt.Errorf("%w: no processes found in input", ErrInvalidArgs)
+	}
+	slog.Debug("loaded processes", "file", f.Name(), "count", len(processes))
+	return processes, checkAnomalies(slog.Default(), strict, anomalies)
+}
+
+func runSchedule(args []string) error {
+	fs := newFlagSet("schedule", "[flags] [processes.csv]")
+	sim := addSimulationFlags(fs)
+	events := fs.Bool("events", false, "print a chronological event log after each schedule")
+	excludeNeverRun := fs.Bool("exclude-never-run", false, "leave processes that never ran, e.g. within -horizon, out of the schedule tables and list them separately")
+	fingerprint := fs.Bool("fingerprint", false, "print a SHA-256 fingerprint of each schedule, for checking it against a reference (see Fingerprint)")
+	cumulative := fs.Bool("cumulative", false, "print the running average wait and turnaround after each completion")
+	columns := fs.String("columns", "", "comma-separated schedule table columns to show, in order, e.g. id,burst,response; empty shows the default ones")
+	groupBy := fs.String("group-by", "", "also print the average wait and turnaround of each cohort of processes: arrival, or arrival:N for buckets N wide")
+	explain := fs.Bool("explain", false, "show how each average and the throughput were computed, with the run's numbers")
+	describe := fs.Bool("describe", false, "print a one-line description of each algorithm's policy before its schedule")
+	format := fs.String("format", "table", "output format: "+strings.Join(outputFormats, ", "))
+	sweep := fs.Bool("sweep-quantum", false, "sweep round-robin quanta and report the best for turnaround and context switches")
+	metricsOut := fs.String("metrics-out", "", "also write every algorithm's metrics as JSON to this file")
+	ganttOut := fs.String("gantt-out", "", "also write every Gantt chart as algorithm,pid,start,stop CSV rows to this file")
+	burndownOut := fs.String("burndown", "", "also write every process's remaining burst at each tick as algorithm,time,pid,remaining CSV rows to this file")
+	ganttScale := fs.Int("gantt-scale", 0, "draw the Gantt chart proportionally with this many characters per time unit; 0 keeps fixed-width cells")
+	generateOut := fs.Bool("generate-out", false, "write the -generate processes to stdout as CSV instead of scheduling them")
+	watch := fs.Bool("watch", false, "re-run the schedules whenever the processes file changes, until interrupted")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if !isOutputFormat(*format) {
+		return fmt.Errorf("%w: unknown format %q, must be one of %s", ErrInvalidArgs, *format, strings.Join(outputFormats, ", "))
+	}
+	grouping, err := parseGroupBy(*groupBy)
+	if err != nil {
+		return err
+	}
+	shownColumns, err := parseColumns(*columns)
+	if err != nil {
+		return err
+	}
+	// run loads the processes file and schedules it once; -watch calls it on every change
+	run := func() error {
+		processes, err := sim.processes(fs.Args())
+		if err != nil {
+			return err
+		}
+		if *generateOut {
+			return writeProcesses(os.Stdout, processes)
+		}
+		if err := checkSchedulable(processes); err != nil {
+			return err
+		}
+		algos, err := selectAlgorithms(*sim.algo)
+		if err != nil {
+			return err
+		}
+		logPriorityNotes(processes, algos)
+		logIONotes(processes, algos)
+		opts, err := sim.options(processes)
+		if err != nil {
+			return err
+		}
+		opts.Events, opts.Describe, opts.Format, opts.GanttScale = *events, *describe, *format, *ganttScale
+		opts.ExcludeNeverRun, opts.Cumulative, opts.Explain = *excludeNeverRun, *cumulative, *explain
+		opts.GroupBy, opts.Columns = grouping, shownColumns
+		opts.Burndown = *burndownOut != ""
+		orders, err := parsePriorityOrders(*sim.priorityOrder)
+		if err != nil {
+			return err
+		}
+
+		if *sweep {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+			outputQuantumSweep(os.Stdout, quantumSweep(ctx, processes, opts))
+			return nil
+		}
+		var hooks []ResultHook
+		if *fingerprint {
+			hooks = append(hooks, func(algo string, res ScheduleResult) {
+				outputRemark(os.Stdout, opts, "Fingerprint: "+Fingerprint(processes, res))
+			})
+		}
+		results := runAlgorithms(os.Stdout, algos, processes, opts, orders, *sim.timeout, hooks...)
+		exported := results
+		if opts.Format != "json" { // the JSON objects have a field per column, whatever -columns asks for
+			exported = make([]ScheduleResult, len(results))
+			for i, res := range results {
+				exported[i] = selectColumns(res, opts.Columns)
+			}
+		}
+		if err := writeResults(os.Stdout, opts.Format, exported); err != nil {
+			return err
+		}
+		if *ganttOut != "" {
+			err := writeOutputFile(*ganttOut, "Gantt CSV", func(w io.Writer) error { return writeGanttCSV(w, results) })
+			if err != nil {
+				return err
+			}
+		}
+		if *burndownOut != "" {
+			err := writeOutputFile(*burndownOut, "burndown CSV", func(w io.Writer) error {
+				return writeBurndownCSV(w, processes, results)
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if *metricsOut != "" {
+			err := writeOutputFile(*metricsOut, "metrics JSON", func(w io.Writer) error { return writeMetricsJSON(w, processes, results) })
+			if err != nil {
+				return err
+			}
+		}
+		if err := checkTickLimits(results); err != nil {
+			return err
+		}
+		if err := checkNegativeTimes(processes, results); err != nil {
+			return err
+		}
+		return checkAnomalies(slog.Default(), *sim.strict, idleAnomalies(results))
+	}
+	if !*watch {
+		return run()
+	}
+	if len(fs.Args()) != 1 {
+		return fmt.Errorf("%w: -watch must be given a scheduling file to process, not stdin", ErrInvalidArgs)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	return watchFile(ctx, os.Stdout, slog.Default(), fs.Args()[0], watchInterval, watchDebounce, run)
+}
+
+func runCompare(args []string) error {
+	fs := newFlagSet("compare", "[flags] [processes.csv]")
+	sim := addSimulationFlags(fs)
+	mini := fs.Bool("mini-gantt", false, "also print every algorithm's Gantt chart as one line of blocks, stacked under the table")
+	report := fs.String("report", "", "also rank the algorithms by this metric and explain the tradeoffs: wait, turnaround, throughput, max-wait, switches or fairness")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	var metric reportMetric
+	if *report != "" {
+		m, err := findReportMetric(*report)
+		if err != nil {
+			return err
+		}
+		metric = m
+	}
+
+	processes, err := sim.processes(fs.Args())
+	if err != nil {
+		return err
+	}
+	if err := checkSchedulable(processes); err != nil {
+		return err
+	}
+	algos, err := selectAlgorithms(*sim.algo)
+	if err != nil {
+		return err
+	}
+	logPriorityNotes(processes, algos)
+	logIONotes(processes, algos)
+	opts, err := sim.options(processes)
+	if err != nil {
+		return err
+	}
+	orders, err := parsePriorityOrders(*sim.priorityOrder)
+	if err != nil {
+		return err
+	}
+	results := runAlgorithms(io.Discard, algos, processes, opts, orders, *sim.timeout)
+	outputComparison(os.Stdout, results)
+	outputBacklog(os.Stdout, opts.Backlog)
+	if *mini {
+		outputMiniGantts(os.Stdout, results)
+	}
+	if *report != "" {
+		outputReport(os.Stdout, results, metric)
+	}
+	if err := checkTickLimits(results); err != nil {
+		return err
+	}
+	if err := checkNegativeTimes(processes, results); err != nil {
+		return err
+	}
+	return checkAnomalies(slog.Default(), *sim.strict, idleAnomalies(results))
+}
+
+// outputComparison prints one summary row per scheduler result.
+func outputComparison(w io.Writer, results []ScheduleResult) {
+	outputTitle(w, "Scheduler comparison")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Algorithm", "Avg wait", "Avg turnaround", "Throughput", "Context switches", "Utilization"})
+	for _, res := range results {
+		title := res.Title
+		if res.Err != nil {
+			title += " (partial)"
+		}
+		table.Append([]string{
+			title,
+			fmt.Sprintf("%.2f", res.AvgWait),
+			fmt.Sprintf("%.2f", res.AvgTurnaround),
+			fmt.Sprintf("%.2f/t", res.Throughput),
+			fmt.Sprint(contextSwitches(res.Gantt)),
+			fmt.Sprintf("%.2f%%", 100*res.Utilization),
+		})
+	}
+	table.Render()
+}
+
+// outputMiniGantts stacks the mini Gantt charts of the results, aligned after their titles and
+// sharing one legend.
+func outputMiniGantts(w io.Writer, results []ScheduleResult) {
+	var (
+		gantts = make([][]TimeSlice, len(results))
+		width  int
+	)
+	for i, res := range results {
+		gantts[i] = res.Gantt
+		if len(res.Title) > width {
+			width = len(res.Title)
+		}
+	}
+	blocks := miniGanttSymbols(gantts...)
+	for _, res := range results {
+		_, _ = fmt.Fprintf(w, "%-*s  %s\n", width, res.Title, miniGanttLine(res.Gantt, blocks))
+	}
+	_, _ = fmt.Fprintln(w, miniGanttLegend(blocks))
+}
+
+func runGenerate(args []string) error {
+	fs := newFlagSet("generate", "[flags]")
+	n := fs.Int("n", 10, "number of processes to generate")
+	seed := fs.Int64("seed", 1, "random seed; the same seed always generates the same workload")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if *n < 1 {
+		return fmt.Errorf("%w: n must be at least 1", ErrInvalidArgs)
+	}
+	return writeProcesses(os.Stdout, GenerateProcesses(*n, *seed))
+}
+
+func runValidate(args []string) error {
+	fs := newFlagSet("validate", "[flags] [processes.csv]")
+	strict := addStrictFlag(fs)
+	delimiter := addDelimiterFlag(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	comma, err := parseDelimiter(*delimiter)
+	if err != nil {
+		return err
+	}
+	processes, err := loadProcessingFile(fs.Args(), *strict, loadOptions{comma: comma})
+	if err != nil {
+		return err
+	}
+	if err := validateProcesses(processes); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(os.Stdout, "OK: %d processes\n", len(processes))
+	return nil
+}
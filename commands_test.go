@@ -0,0 +1,328 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_splitCommand(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		args     []string
+		wantName string
+		wantArgs []string
+	}{
+		{name: "no args", args: nil, wantName: "schedule", wantArgs: nil},
+		{name: "file only", args: []string{"procs.csv"}, wantName: "schedule", wantArgs: []string{"procs.csv"}},
+		{name: "flags only", args: []string{"-events", "procs.csv"}, wantName: "schedule", wantArgs: []string{"-events", "procs.csv"}},
+		{name: "subcommand", args: []string{"compare", "-timeout", "1s", "procs.csv"}, wantName: "compare", wantArgs: []string{"-timeout", "1s", "procs.csv"}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd, args := splitCommand(tt.args)
+			if cmd.name != tt.wantName {
+				t.Errorf("splitCommand() command = %v, want %v", cmd.name, tt.wantName)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("splitCommand() args = %v, want %v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func Test_runAlgorithmsHooks(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+	}
+	var (
+		titles []string
+		seen   []ScheduleResult
+	)
+	results := runAlgorithms(io.Discard, algorithms, processes, SchedulerOptions{}, nil, 0, func(algo string, res ScheduleResult) {
+		titles = append(titles, algo)
+		seen = append(seen, res)
+	})
+
+	if len(titles) != len(algorithms) {
+		t.Fatalf("hook called %d times, want %d", len(titles), len(algorithms))
+	}
+	for i, algo := range algorithms {
+		if titles[i] != algo.title {
+			t.Errorf("hook call %d algo = %q, want %q", i, titles[i], algo.title)
+		}
+	}
+	if !reflect.DeepEqual(seen, results) {
+		t.Error("hook results differ from the returned results")
+	}
+}
+
+func Test_runAlgorithmsDescribe(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, Priority: 1}}
+	var w bytes.Buffer
+	runAlgorithms(&w, algorithms, processes, SchedulerOptions{Describe: true}, nil, 0)
+	for _, algo := range algorithms {
+		if want := algo.title + ": " + algo.description + "\n"; !strings.Contains(w.String(), want) {
+			t.Errorf("output is missing the description %q", want)
+		}
+	}
+}
+
+func Test_selectAlgorithms(t *testing.T) {
+	t.Parallel()
+	all, err := selectAlgorithms("")
+	if err != nil || !reflect.DeepEqual(all, algorithms) {
+		t.Errorf("selectAlgorithms(\"\") = %v, %v, want every algorithm", all, err)
+	}
+
+	got, err := selectAlgorithms("rr, fcfs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, algo := range got {
+		names = append(names, algo.name)
+	}
+	if want := []string{"fcfs", "rr"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("selectAlgorithms() = %v, want %v in the order they always run", names, want)
+	}
+
+	_, err = selectAlgorithms("fcfs,lottery")
+	if !errors.Is(err, ErrInvalidArgs) || !strings.Contains(err.Error(), `"lottery"`) || !strings.Contains(err.Error(), "fcfs, sjf") {
+		t.Errorf("selectAlgorithms() error = %v, want %v naming the unknown algorithm and the valid ones", err, ErrInvalidArgs)
+	}
+}
+
+func Test_outputComparison(t *testing.T) {
+	t.Parallel()
+	results := []ScheduleResult{
+		{Title: "First-come, first-serve", AvgWait: 1.5, AvgTurnaround: 4, Throughput: 0.25, Utilization: 0.8, Gantt: []TimeSlice{{PID: 1, Stop: 2}, {PID: 2, Start: 3, Stop: 5}}},
+		{Title: "Round-robin", Err: ErrHorizon},
+	}
+	var w bytes.Buffer
+	outputComparison(&w, results)
+	for _, want := range []string{"UTILIZATION", "80.00%", "Round-robin (partial)"} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("outputComparison() is missing %q:\n%s", want, w.String())
+		}
+	}
+}
+
+func Test_parsePriorityOrders(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		spec    string
+		want    map[string]PriorityOrder
+		wantErr bool
+	}{
+		{name: "empty", spec: "", want: map[string]PriorityOrder{}},
+		{
+			name: "per algorithm",
+			spec: "priority=higher,sjf-priority=lower",
+			want: map[string]PriorityOrder{"priority": HigherFirst, "sjf-priority": LowerFirst},
+		},
+		{name: "missing order", spec: "priority", wantErr: true},
+		{name: "unknown order", spec: "priority=first", wantErr: true},
+		{name: "unknown algorithm", spec: "lottery=higher", wantErr: true},
+		{name: "ignores priorities", spec: "rr=higher", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parsePriorityOrders(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePriorityOrders() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidArgs) {
+				t.Errorf("parsePriorityOrders() error = %v, want %v", err, ErrInvalidArgs)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parsePriorityOrders() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseFlags(t *testing.T) {
+	t.Setenv("SCHED_FORMAT", "dot")
+	t.Setenv("SCHED_PRIORITY_ORDER", "priority=higher")
+
+	fs := newFlagSet("schedule", "[flags] [processes.csv]")
+	format := fs.String("format", "table", "")
+	order := fs.String("priority-order", "", "")
+	events := fs.Bool("events", false, "")
+	if err := parseFlags(fs, []string{"-priority-order", "priority=lower", "procs.csv"}); err != nil {
+		t.Fatal(err)
+	}
+	if *format != "dot" {
+		t.Errorf("format = %q, want the environment's %q", *format, "dot")
+	}
+	if *order != "priority=lower" {
+		t.Errorf("priority-order = %q, want the flag's %q", *order, "priority=lower")
+	}
+	if *events {
+		t.Errorf("events = %v, want the default %v", *events, false)
+	}
+
+	t.Setenv("SCHED_EVENTS", "sometimes")
+	fs = newFlagSet("schedule", "[flags] [processes.csv]")
+	fs.Bool("events", false, "")
+	if err := parseFlags(fs, nil); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("parseFlags() error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
+
+func Test_priorityNotes(t *testing.T) {
+	t.Parallel()
+	var (
+		agnostic    = []algorithm{{name: "fcfs", title: "First-come, first-serve"}, {name: "rr", title: "Round-robin"}}
+		aware       = append(agnostic, algorithm{name: "priority", title: "Preemptive priority", priority: true})
+		prioritised = []Process{{ProcessID: 1, Priority: 2}, {ProcessID: 2}}
+		unset       = []Process{{ProcessID: 1}, {ProcessID: 2}}
+	)
+	tests := []struct {
+		name      string
+		processes []Process
+		algos     []algorithm
+		want      []string
+	}{
+		{name: "priorities used", processes: prioritised, algos: aware},
+		{name: "no priorities needed", processes: unset, algos: agnostic},
+		{name: "no processes", algos: aware},
+		{
+			name: "priorities unused", processes: prioritised, algos: agnostic,
+			want: []string{"the priority column is unused: none of the algorithms that run uses priorities"},
+		},
+		{
+			name: "priorities missing", processes: unset, algos: aware,
+			want: []string{"every priority is 0, so the priority-aware algorithms (Preemptive priority) treat all processes alike: the priority column is missing or defaulted"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := priorityNotes(tt.processes, tt.algos); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("priorityNotes() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_simulationFlagsQuantum(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		args    []string
+		want    int64
+		wantErr bool
+	}{
+		{args: nil, want: defaultQuantum},
+		{args: []string{"-quantum", "5"}, want: 5},
+		{args: []string{"-quantum", "0"}, wantErr: true},
+	} {
+		fs := newFlagSet("schedule", "[flags] [processes.csv]")
+		sim := addSimulationFlags(fs)
+		if err := fs.Parse(tt.args); err != nil {
+			t.Fatal(err)
+		}
+		opts, err := sim.options(nil)
+		if (err != nil) != tt.wantErr || (err != nil && !errors.Is(err, ErrInvalidArgs)) {
+			t.Errorf("options() with %v error = %v, wantErr %v", tt.args, err, tt.wantErr)
+		}
+		if !tt.wantErr && opts.Quantum != tt.want {
+			t.Errorf("options() with %v Quantum = %d, want %d", tt.args, opts.Quantum, tt.want)
+		}
+	}
+}
+
+func Test_simulationFlagsGenerate(t *testing.T) {
+	t.Parallel()
+	fs := newFlagSet("schedule", "[flags] [processes.csv]")
+	sim := addSimulationFlags(fs)
+	if err := fs.Parse([]string{"-generate", "5", "-seed", "7"}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := sim.processes(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := GenerateProcesses(5, 7); !reflect.DeepEqual(got, want) {
+		t.Errorf("processes() = %v, want GenerateProcesses(5, 7) = %v", got, want)
+	}
+	if _, err := sim.processes([]string{"procs.csv"}); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("processes() with a file error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
+
+// Test_RegisterScheduler is not parallel: it adds to algorithms, which the other tests range over.
+func Test_RegisterScheduler(t *testing.T) {
+	builtin := algorithms
+	t.Cleanup(func() { algorithms = builtin })
+
+	idle := SchedulerFunc(func(_ context.Context, _ io.Writer, title string, _ []Process, _ SchedulerOptions) ScheduleResult {
+		return ScheduleResult{Title: title}
+	})
+	if err := RegisterScheduler("idle", "Idle", "never runs anything", idle); err != nil {
+		t.Fatal(err)
+	}
+	for _, bad := range []struct {
+		name string
+		s    Scheduler
+	}{{name: "fcfs", s: idle}, {name: "", s: idle}, {name: "none"}} {
+		if err := RegisterScheduler(bad.name, "Bad", "", bad.s); !errors.Is(err, ErrInvalidArgs) {
+			t.Errorf("RegisterScheduler(%q) error = %v, want %v", bad.name, err, ErrInvalidArgs)
+		}
+	}
+
+	results := runAlgorithms(io.Discard, algorithms, []Process{{ProcessID: 1, BurstDuration: 2}}, SchedulerOptions{}, nil, 0)
+	if len(results) != len(builtin)+1 || results[len(results)-1].Title != "Idle" {
+		t.Errorf("runAlgorithms() ran %d schedulers, want the %d built-in ones followed by Idle", len(results), len(builtin))
+	}
+}
+
+func Test_loadProcessingFile(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	for _, tt := range []struct {
+		name     string
+		contents string
+		want     []Process
+		wantErr  bool
+	}{
+		{name: "empty", contents: "", wantErr: true},
+		{name: "header only", contents: "ProcessID,BurstDuration\n", wantErr: true},
+		{name: "one row", contents: "1,4,0,1\n", want: []Process{{ProcessID: 1, BurstDuration: 4, Priority: 1, Weight: 1}}},
+	} {
+		file := filepath.Join(dir, tt.name+".csv")
+		if err := os.WriteFile(file, []byte(tt.contents), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		got, err := loadProcessingFile([]string{file}, false, loadOptions{})
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidArgs) || !strings.Contains(err.Error(), "no processes found in input") {
+				t.Errorf("loadProcessingFile() %s error = %v, want no processes found", tt.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("loadProcessingFile() %s error = %v", tt.name, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("loadProcessingFile() %s = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// CumulativeMetrics are the running averages of a schedule right after one process exited.
+type CumulativeMetrics struct {
+	Time      int64
+	PID       int64
+	Completed int
+	// AvgWait and AvgTurnaround average over the Completed processes that exited so far.
+	AvgWait       float64
+	AvgTurnaround float64
+}
+
+// cumulativeMetrics replays the completions of a schedule in exit order, ties broken by PID, and
+// returns the running averages after each one. Unfinished processes are left out.
+func cumulativeMetrics(processes []Process, pd []ProcessData) []CumulativeMetrics {
+	exited := make([]int, 0, len(pd))
+	for i := range pd {
+		if pd[i].ExitTime != 0 {
+			exited = append(exited, i)
+		}
+	}
+	sort.Slice(exited, func(a, b int) bool {
+		i, j := exited[a], exited[b]
+		if pd[i].ExitTime != pd[j].ExitTime {
+			return pd[i].ExitTime < pd[j].ExitTime
+		}
+		return processes[i].ProcessID < processes[j].ProcessID
+	})
+
+	var (
+		metrics                    = make([]CumulativeMetrics, len(exited))
+		totalWait, totalTurnaround float64
+	)
+	for n, i := range exited {
+		totalWait += float64(pd[i].TotalWait)
+		totalTurnaround += float64(pd[i].TAround)
+		metrics[n] = CumulativeMetrics{
+			Time:          pd[i].ExitTime,
+			PID:           processes[i].ProcessID,
+			Completed:     n + 1,
+			AvgWait:       totalWait / float64(n+1),
+			AvgTurnaround: totalTurnaround / float64(n+1),
+		}
+	}
+	return metrics
+}
+
+// outputCumulative renders the running averages as a table, one row per completion.
+func outputCumulative(w io.Writer, opts SchedulerOptions, metrics []CumulativeMetrics) {
+	if !opts.Cumulative {
+		return
+	}
+	header := []string{"Exit", "ID", "Completed", "Avg wait", "Avg turnaround"}
+	rows := make([][]string, len(metrics))
+	for i, m := range metrics {
+		rows[i] = []string{
+			fmt.Sprint(m.Time),
+			fmt.Sprint(m.PID),
+			fmt.Sprint(m.Completed),
+			fmt.Sprintf("%.2f", m.AvgWait),
+			fmt.Sprintf("%.2f", m.AvgTurnaround),
+		}
+	}
+
+	_, _ = fmt.Fprintln(w, "Cumulative averages")
+	if opts.Format == "plain" {
+		writePlainTable(w, header, rows)
+		return
+	}
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(header)
+	table.AppendBulk(rows)
+	table.Render()
+}
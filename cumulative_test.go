@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func Test_cumulativeMetrics(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 1, Priority: 3},
+	}
+	res := PrioritySchedule(context.Background(), io.Discard, "Preemptive priority", processes, SchedulerOptions{Cumulative: true})
+	// P2 preempts P1 at t=1 and exits at t=3, then P1 finishes before P3
+	want := []CumulativeMetrics{
+		{Time: 3, PID: 2, Completed: 1, AvgWait: 0, AvgTurnaround: 2},
+		{Time: 7, PID: 1, Completed: 2, AvgWait: 1, AvgTurnaround: 4.5},
+		{Time: 8, PID: 3, Completed: 3, AvgWait: 2.3333333333333335, AvgTurnaround: 5},
+	}
+	if !reflect.DeepEqual(res.Cumulative, want) {
+		t.Errorf("Cumulative = %v, want %v", res.Cumulative, want)
+	}
+	if last := res.Cumulative[len(res.Cumulative)-1]; last.AvgWait != res.AvgWait || last.AvgTurnaround != res.AvgTurnaround {
+		t.Errorf("final cumulative averages = %v/%v, want the schedule's %v/%v", last.AvgWait, last.AvgTurnaround, res.AvgWait, res.AvgTurnaround)
+	}
+
+	res = PrioritySchedule(context.Background(), io.Discard, "Preemptive priority", processes, SchedulerOptions{Horizon: 4, Cumulative: true})
+	if len(res.Cumulative) != 1 || res.Cumulative[0].PID != 2 {
+		t.Errorf("Cumulative within horizon = %v, want only P2", res.Cumulative)
+	}
+}
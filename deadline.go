@@ -0,0 +1,135 @@
+package main
+
+import "fmt"
+
+// DeadlineCheck is whether a process with a deadline exited by it.
+type DeadlineCheck struct {
+	PID      int64
+	Deadline int64
+	// Exit is zero if the process never finished, which misses the deadline.
+	Exit int64
+	Met  bool
+}
+
+// Lateness is how long after its deadline the process exited, negative if it finished early.
+func (c DeadlineCheck) Lateness() int64 {
+	return c.Exit - c.Deadline
+}
+
+// hasDeadlines reports whether any process has a deadline.
+func hasDeadlines(processes []Process) bool {
+	for i := range processes {
+		if processes[i].Deadline != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// checkDeadlines compares the exit of every process that has a deadline against it, in input order.
+func checkDeadlines(processes []Process, pd []ProcessData) []DeadlineCheck {
+	var checks []DeadlineCheck
+	for i, p := range processes {
+		if p.Deadline == 0 {
+			continue
+		}
+		checks = append(checks, DeadlineCheck{
+			PID:      p.ProcessID,
+			Deadline: p.Deadline,
+			Exit:     pd[i].ExitTime,
+			Met:      deadlineMet(p, pd[i]),
+		})
+	}
+	return checks
+}
+
+// deadlineMet reports whether a process with a deadline exited by it; an unfinished process
+// misses it.
+func deadlineMet(p Process, proc ProcessData) bool {
+	return proc.ExitTime != 0 && proc.ExitTime <= p.Deadline
+}
+
+// missedDeadlines counts the processes with a deadline that missed it. pd may be shorter than
+// processes when a first-come, first-serve run was cancelled before reaching the rest, which
+// are unfinished and so miss their deadlines too.
+func missedDeadlines(processes []Process, pd []ProcessData) int {
+	var missed int
+	for i, p := range processes {
+		if p.Deadline != 0 && (i >= len(pd) || !deadlineMet(p, pd[i])) {
+			missed++
+		}
+	}
+	return missed
+}
+
+// deadlineCell renders a process's deadline for the schedule table, leaving a dash for a
+// process without one.
+func deadlineCell(p Process) string {
+	if p.Deadline == 0 {
+		return "-"
+	}
+	return fmt.Sprint(p.Deadline)
+}
+
+// missedCell renders whether a process missed its deadline for the schedule table, leaving a
+// dash for a process without one.
+func missedCell(p Process, proc ProcessData) string {
+	if p.Deadline == 0 {
+		return "-"
+	}
+	return fmt.Sprint(!deadlineMet(p, proc))
+}
+
+// deadlineNotes reports each deadline as met or missed, then how many were met under the quantum
+// and the worst lateness among the processes that finished.
+func deadlineNotes(checks []DeadlineCheck, quantum int) []string {
+	var (
+		notes []string
+		met   int
+		worst int64
+		late  bool // some finished process has a lateness, so worst is set
+	)
+	for _, c := range checks {
+		switch {
+		case c.Exit == 0:
+			notes = append(notes, fmt.Sprintf("P%d missed deadline %d: unfinished", c.PID, c.Deadline))
+		case c.Met:
+			notes = append(notes, fmt.Sprintf("P%d met deadline %d with %d to spare", c.PID, c.Deadline, -c.Lateness()))
+		default:
+			notes = append(notes, fmt.Sprintf("P%d missed deadline %d by %d", c.PID, c.Deadline, c.Lateness()))
+		}
+		if c.Met {
+			met++
+		}
+		if c.Exit != 0 && (!late || c.Lateness() > worst) {
+			worst, late = c.Lateness(), true
+		}
+	}
+	summary := fmt.Sprintf("Deadlines met under quantum %d: %d of %d", quantum, met, len(checks))
+	if late {
+		summary += fmt.Sprintf(", worst-case lateness %d", worst)
+	}
+	return append(notes, summary)
+}
+
+// laxity is how much longer a process dispatched at time with remaining burst left could wait and
+// still meet its deadline: deadline − time − remaining. A negative laxity means the deadline can
+// no longer be met. It is zero for a process without a deadline.
+func laxity(p Process, time, remaining int64) int64 {
+	if p.Deadline == 0 {
+		return 0
+	}
+	return p.Deadline - time - remaining
+}
+
+// laxityCell renders a process's laxity for the schedule table, flagging an unmeetable deadline
+// and leaving a dash for a process without a deadline or that never ran.
+func laxityCell(p Process, proc ProcessData) string {
+	switch {
+	case p.Deadline == 0 || proc.FirstRun < 0:
+		return "-"
+	case proc.Laxity < 0:
+		return fmt.Sprintf("%d (unmeetable)", proc.Laxity)
+	}
+	return fmt.Sprint(proc.Laxity)
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestRRScheduleDeadlines(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3, Deadline: 6},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2, Deadline: 1},
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 1},
+	}
+	res := RRSchedule(context.Background(), io.Discard, "Round-robin", processes, SchedulerOptions{})
+	// P2 runs [0, 2), P3 [2, 3) and P1 [3, 6)
+	wantChecks := []DeadlineCheck{
+		{PID: 1, Deadline: 6, Exit: 6, Met: true},
+		{PID: 2, Deadline: 1, Exit: 2},
+	}
+	if !reflect.DeepEqual(res.Deadlines, wantChecks) {
+		t.Errorf("Deadlines = %v, want %v", res.Deadlines, wantChecks)
+	}
+	wantNotes := []string{
+		"P1 met deadline 6 with 0 to spare",
+		"P2 missed deadline 1 by 1",
+		"Deadlines met under quantum 2: 1 of 2, worst-case lateness 1",
+	}
+	if !reflect.DeepEqual(res.Notes, wantNotes) {
+		t.Errorf("Notes = %q, want %q", res.Notes, wantNotes)
+	}
+
+	res = RRSchedule(context.Background(), io.Discard, "Round-robin", processes, SchedulerOptions{Horizon: 5})
+	want := []string{
+		"P1 missed deadline 6: unfinished",
+		"P2 missed deadline 1 by 1",
+		"Deadlines met under quantum 2: 0 of 2, worst-case lateness 1",
+	}
+	if got := res.Notes[len(res.Notes)-len(want):]; !reflect.DeepEqual(got, want) {
+		t.Errorf("Notes within horizon = %q, want %q", got, want)
+	}
+}
+
+func TestSchedulersLaxity(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3, Priority: 2, Deadline: 8},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2, Priority: 1, Deadline: 4},
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 1, Priority: 3},
+	}
+	tests := []struct {
+		name       string
+		schedule   func(context.Context, io.Writer, string, []Process, SchedulerOptions) ScheduleResult
+		wantLaxity []int64
+	}{
+		// P1 runs [0, 3), P2 [3, 5) and P3 [5, 6)
+		{name: "first-come, first-serve", schedule: FCFSSchedule, wantLaxity: []int64{5, -1, 0}},
+		// P1 runs [0, 1), P2 [1, 3), P1 again [3, 5) with 2 left, then P3
+		{name: "priority", schedule: PrioritySchedule, wantLaxity: []int64{3, 1, 0}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			res := tt.schedule(context.Background(), io.Discard, tt.name, processes, SchedulerOptions{})
+			for i, want := range tt.wantLaxity {
+				if got := res.Data[i].Laxity; got != want {
+					t.Errorf("P%d Laxity = %d, want %d", processes[i].ProcessID, got, want)
+				}
+			}
+			if got := res.Header[len(res.Header)-1]; got != "Laxity" {
+				t.Errorf("last column = %q, want %q", got, "Laxity")
+			}
+			if got := res.Rows[2][len(res.Header)-1]; got != "-" {
+				t.Errorf("P3 laxity cell = %q, want %q", got, "-")
+			}
+		})
+	}
+}
+
+func Test_laxityCell(t *testing.T) {
+	t.Parallel()
+	p := Process{ProcessID: 1, Deadline: 5}
+	tests := []struct {
+		p    Process
+		proc ProcessData
+		want string
+	}{
+		{p: p, proc: ProcessData{Laxity: 2}, want: "2"},
+		{p: p, proc: ProcessData{Laxity: -3}, want: "-3 (unmeetable)"},
+		{p: p, proc: ProcessData{FirstRun: -1}, want: "-"},
+		{p: Process{ProcessID: 2}, proc: ProcessData{}, want: "-"},
+	}
+	for _, tt := range tests {
+		if got := laxityCell(tt.p, tt.proc); got != tt.want {
+			t.Errorf("laxityCell(%v, %v) = %q, want %q", tt.p, tt.proc, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"unicode/utf8"
+)
+
+// addDelimiterFlag registers the -delimiter flag that parseDelimiter reads.
+func addDelimiterFlag(fs *flag.FlagSet) *string {
+	return fs.String("delimiter", ",", `character separating the cells of the processes file, e.g. ";", or "\t" or "tab" for TSV`)
+}
+
+// parseDelimiter parses the -delimiter flag into the rune separating a process file's cells:
+// a single character, or `\t` or "tab" for a tab, since shells make a literal tab awkward to
+// type. It rejects the characters encoding/csv can't separate cells with, such as a quote.
+func parseDelimiter(spec string) (rune, error) {
+	switch spec {
+	case `\t`, "tab":
+		return '\t', nil
+	}
+	r, size := utf8.DecodeRuneInString(spec)
+	if size == 0 || size != len(spec) {
+		return 0, fmt.Errorf("%w: delimiter must be a single character, got %q", ErrInvalidArgs, spec)
+	}
+	switch r {
+	case '"', '\r', '\n', commentChar, utf8.RuneError:
+		return 0, fmt.Errorf("%w: delimiter %q can't separate CSV cells", ErrInvalidArgs, spec)
+	}
+	return r, nil
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_parseDelimiter(t *testing.T) {
+	t.Parallel()
+	for spec, want := range map[string]rune{",": ',', ";": ';', `\t`: '\t', "tab": '\t', "\t": '\t', "|": '|'} {
+		if got, err := parseDelimiter(spec); err != nil || got != want {
+			t.Errorf("parseDelimiter(%q) = %q, %v, want %q", spec, got, err, want)
+		}
+	}
+	for _, spec := range []string{"", ";;", `"`, "\n", "#"} {
+		if _, err := parseDelimiter(spec); !errors.Is(err, ErrInvalidArgs) {
+			t.Errorf("parseDelimiter(%q) error = %v, want %v", spec, err, ErrInvalidArgs)
+		}
+	}
+}
+
+func Test_readProcessesDelimiter(t *testing.T) {
+	t.Parallel()
+	want := []Process{
+		{ProcessID: 1, BurstDuration: 5, ArrivalTime: 0, Priority: 2.5, Weight: 1},
+		{ProcessID: 2, BurstDuration: 3, ArrivalTime: 1, Priority: 1, Weight: 1},
+	}
+	for comma, input := range map[rune]string{
+		'\t': "ProcessID\tBurstDuration\tArrivalTime\tPriority\n1\t5\t0\t2.5\n2\t3\t1\t1\n",
+		';':  "1;5;0;2.5\n2;3;1;1\n",
+	} {
+		got, _, err := readProcessesWith(strings.NewReader(input), loadOptions{comma: comma})
+		if err != nil {
+			t.Fatalf("readProcessesWith(%q) error = %v", comma, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("readProcessesWith(%q) = %v, want %v", comma, got, want)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"io"
+)
+
+// nearerDeadline reports whether deadline a is due before deadline b, where zero means the process
+// has none and so comes after every process that has one.
+func nearerDeadline(a, b int64) bool {
+	switch {
+	case a == 0:
+		return false
+	case b == 0:
+		return true
+	}
+	return a < b
+}
+
+// EDFSchedule outputs a preemptive earliest-deadline-first schedule: every tick the released,
+// unfinished process with the nearest absolute deadline (see Process.Deadline) runs, ties broken
+// by arrival then PID. Processes without a deadline only run when no process with one is ready.
+func EDFSchedule(ctx context.Context, w io.Writer, title string, processes []Process, opts SchedulerOptions) ScheduleResult {
+	s := runTicks(ctx, title, processes, opts, tickPolicy{next: func(s *tickSim) int {
+		next := -1
+		for i, p := range processes {
+			if !s.ready(i) {
+				continue
+			}
+			if next < 0 {
+				next = i
+				continue
+			}
+			q := processes[next]
+			if nearerDeadline(p.Deadline, q.Deadline) ||
+				(p.Deadline == q.Deadline && p.ArrivalTime < q.ArrivalTime) ||
+				(p.Deadline == q.Deadline && p.ArrivalTime == q.ArrivalTime && p.ProcessID < q.ProcessID) {
+				next = i
+			}
+		}
+		return next
+	}})
+
+	res := s.result(title)
+	outputResult(w, opts, res)
+	return res
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEDFSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4, Deadline: 10},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2, Deadline: 4},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 1},
+		{ProcessID: 4, ArrivalTime: 2, BurstDuration: 2, Deadline: 4},
+	}
+	// P2 preempts P1 and wins the tie on deadline 4 by arriving first, so P4 exits late at t=5;
+	// P3 has no deadline and runs last
+	wantGantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 1},
+		{PID: 2, Start: 1, Stop: 3},
+		{PID: 4, Start: 3, Stop: 5},
+		{PID: 1, Start: 5, Stop: 8},
+		{PID: 3, Start: 8, Stop: 9},
+	}
+	var w bytes.Buffer
+	res := EDFSchedule(context.Background(), &w, "Earliest-deadline-first", processes, SchedulerOptions{Format: "plain"})
+	if !reflect.DeepEqual(res.Gantt, wantGantt) {
+		t.Errorf("Gantt = %v, want %v", res.Gantt, wantGantt)
+	}
+	if res.MissedDeadlines != 1 {
+		t.Errorf("MissedDeadlines = %d, want 1", res.MissedDeadlines)
+	}
+
+	deadline, missed := len(res.Header)-3, len(res.Header)-2
+	if got := res.Header[deadline : missed+1]; !reflect.DeepEqual(got, []string{"Deadline", "Missed"}) {
+		t.Fatalf("Header = %v, want Deadline and Missed before Laxity", res.Header)
+	}
+	for i, want := range [][2]string{{"10", "false"}, {"4", "false"}, {"-", "-"}, {"4", "true"}} {
+		if got := [2]string{res.Rows[i][deadline], res.Rows[i][missed]}; got != want {
+			t.Errorf("P%d deadline and missed cells = %v, want %v", processes[i].ProcessID, got, want)
+		}
+	}
+	if !strings.Contains(w.String(), ", missed deadlines 1\n") {
+		t.Errorf("output does not report the missed deadline:\n%s", w.String())
+	}
+}
+
+func TestEDFScheduleWithoutDeadlines(t *testing.T) {
+	t.Parallel()
+	// with no deadlines every tie falls to arrival, so EDF runs like first-come, first-serve
+	processes := []Process{
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2},
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3},
+	}
+	res := EDFSchedule(context.Background(), io.Discard, "Earliest-deadline-first", processes, SchedulerOptions{})
+	want := FCFSSchedule(context.Background(), io.Discard, "First-come, first-serve", processes, SchedulerOptions{})
+	if !reflect.DeepEqual(res.Gantt, want.Gantt) {
+		t.Errorf("Gantt = %v, want FCFS's %v", res.Gantt, want.Gantt)
+	}
+	if got := scheduleHeader(scheduleColumns(processes, SchedulerOptions{})); !reflect.DeepEqual(res.Header, got) {
+		t.Errorf("Header = %v, want no deadline columns %v", res.Header, got)
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// explainMetrics shows the arithmetic behind a schedule's averages and throughput with the
+// numbers of the processes that completed, e.g. "Average wait = Σ wait_i / N = 21/4 = 5.25".
+func explainMetrics(res ScheduleResult) []string {
+	var totalWait, totalTurnaround, completed int64
+	for _, proc := range res.Data {
+		if proc.ExitTime == 0 {
+			continue
+		}
+		totalWait += proc.TotalWait
+		totalTurnaround += proc.TAround
+		completed++
+	}
+	if completed == 0 {
+		return []string{"No process completed, so there is nothing to average"}
+	}
+	return []string{
+		fmt.Sprintf("Average wait = Σ wait_i / N = %d/%d = %.2f", totalWait, completed, res.AvgWait),
+		fmt.Sprintf("Average turnaround = Σ turnaround_i / N = %d/%d = %.2f", totalTurnaround, completed, res.AvgTurnaround),
+		fmt.Sprintf("Throughput = N / elapsed = %d/%d = %.2f/t", completed, res.StoppedAt, res.Throughput),
+	}
+}
+
+// outputExplain prints the explanation of the metrics when requested.
+func outputExplain(w io.Writer, opts SchedulerOptions, res ScheduleResult) {
+	if !opts.Explain {
+		return
+	}
+	_, _ = fmt.Fprintln(w, "How the metrics were computed")
+	for _, line := range explainMetrics(res) {
+		_, _ = fmt.Fprintln(w, line)
+	}
+}
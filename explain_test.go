@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func Test_explainMetrics(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 1, Priority: 3},
+	}
+	res := PrioritySchedule(context.Background(), io.Discard, "Preemptive priority", processes, SchedulerOptions{})
+	want := []string{
+		"Average wait = Σ wait_i / N = 7/3 = 2.33",
+		"Average turnaround = Σ turnaround_i / N = 15/3 = 5.00",
+		"Throughput = N / elapsed = 3/8 = 0.38/t",
+	}
+	if got := explainMetrics(res); !reflect.DeepEqual(got, want) {
+		t.Errorf("explainMetrics() = %q, want %q", got, want)
+	}
+
+	res = PrioritySchedule(context.Background(), io.Discard, "Preemptive priority", processes, SchedulerOptions{Horizon: 2})
+	if got := explainMetrics(res); len(got) != 1 {
+		t.Errorf("explainMetrics() without completions = %q, want one line", got)
+	}
+}
@@ -0,0 +1,361 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// IdlePID labels the time slices in which no process ran.
+const IdlePID int64 = -1
+
+// withIdle returns the Gantt chart with an IdlePID slice filling every gap from t=0 between the
+// slices in which a process ran.
+func withIdle(gantt []TimeSlice) []TimeSlice {
+	var (
+		clock  int64
+		filled = make([]TimeSlice, 0, len(gantt))
+	)
+	for _, slice := range gantt {
+		if slice.Start == slice.Stop {
+			continue // nothing ran
+		}
+		if slice.Start > clock {
+			filled = append(filled, TimeSlice{PID: IdlePID, Start: clock, Stop: slice.Start})
+		}
+		filled = append(filled, slice)
+		if slice.Stop > clock {
+			clock = slice.Stop
+		}
+	}
+	return filled
+}
+
+// writeGanttCSV writes every result's Gantt chart, idle time included, as algorithm,pid,start,stop
+// rows under a header row.
+func writeGanttCSV(w io.Writer, results []ScheduleResult) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"algorithm", "pid", "start", "stop"}); err != nil {
+		return err
+	}
+	for _, res := range results {
+		for _, slice := range withIdle(res.Gantt) {
+			row := []string{res.Title, fmt.Sprint(slice.PID), fmt.Sprint(slice.Start), fmt.Sprint(slice.Stop)}
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeBurndownCSV writes every process's remaining burst at each tick of every result as
+// algorithm,time,pid,remaining rows under a header row, from the snapshots the schedulers recorded
+// under SchedulerOptions.Burndown, so work lost to the preemption penalty shows up as the
+// remaining burst growing again, and neither dispatcher overhead nor I/O lowers it. A result
+// without snapshots writes no rows. The rows are streamed since there are as many as ticks times
+// processes.
+func writeBurndownCSV(w io.Writer, processes []Process, results []ScheduleResult) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"algorithm", "time", "pid", "remaining"}); err != nil {
+		return err
+	}
+	for _, res := range results {
+		for _, tick := range res.Burndown {
+			for i, p := range processes {
+				row := []string{res.Title, fmt.Sprint(tick.Time), fmt.Sprint(p.ProcessID), fmt.Sprint(tick.Remaining[i])}
+				if err := cw.Write(row); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// metricsJSON is the JSON form of a schedule result's metrics; field order is the output order.
+type metricsJSON struct {
+	Algorithm        string               `json:"algorithm"`
+	Partial          bool                 `json:"partial"`
+	StoppedAt        int64                `json:"stopped_at"`
+	Completed        int                  `json:"completed"`
+	AvgWait          float64              `json:"avg_wait"`
+	AvgTurnaround    float64              `json:"avg_turnaround"`
+	Throughput       float64              `json:"throughput"`
+	Utilization      float64              `json:"utilization"`
+	AvgResponse      float64              `json:"avg_response"`
+	WeightedResponse float64              `json:"weighted_response"`
+	ContextSwitches  int                  `json:"context_switches"`
+	FairnessIndex    float64              `json:"fairness_index"`
+	LostWork         int64                `json:"lost_work"`
+	Makespan         *makespanJSON        `json:"makespan,omitempty"`
+	Processes        []processMetricsJSON `json:"processes"`
+}
+
+type makespanJSON struct {
+	Makespan   int64 `json:"makespan"`
+	Busy       int64 `json:"busy"`
+	LowerBound int64 `json:"lower_bound"`
+	ForcedIdle int64 `json:"forced_idle"`
+}
+
+type processMetricsJSON struct {
+	PID        int64  `json:"pid"`
+	Wait       int64  `json:"wait"`
+	Turnaround *int64 `json:"turnaround"` // null for a process that never exited
+	Exit       *int64 `json:"exit"`
+	FirstRun   int64  `json:"first_run"`
+	LostWork   int64  `json:"lost_work"`
+	Remaining  int64  `json:"remaining"`
+}
+
+// writeMetricsJSON writes every result's metrics, including the per-process ones, as a JSON
+// array in algorithm order.
+func writeMetricsJSON(w io.Writer, processes []Process, results []ScheduleResult) error {
+	all := make([]metricsJSON, 0, len(results))
+	for _, res := range results {
+		m := metricsJSON{
+			Algorithm:        res.Title,
+			Partial:          res.Err != nil,
+			StoppedAt:        res.StoppedAt,
+			Completed:        res.Completed(),
+			AvgWait:          res.AvgWait,
+			AvgTurnaround:    res.AvgTurnaround,
+			Throughput:       res.Throughput,
+			Utilization:      res.Utilization,
+			AvgResponse:      res.AvgResponse,
+			WeightedResponse: res.WeightedResponse,
+			ContextSwitches:  res.ContextSwitches,
+			FairnessIndex:    res.FairnessIndex,
+			LostWork:         res.LostWork,
+			Processes:        make([]processMetricsJSON, len(res.Data)),
+		}
+		if g := res.MakespanGap; g.Makespan > 0 {
+			m.Makespan = &makespanJSON{Makespan: g.Makespan, Busy: g.Busy, LowerBound: g.LowerBound, ForcedIdle: g.ForcedIdle}
+		}
+		for i, proc := range res.Data {
+			m.Processes[i] = processMetricsJSON{
+				PID:       processes[i].ProcessID,
+				Wait:      proc.TotalWait,
+				FirstRun:  proc.FirstRun,
+				LostWork:  proc.LostWork,
+				Remaining: proc.Remaining,
+			}
+			if proc.ExitTime != 0 {
+				turnaround, exit := proc.TAround, proc.ExitTime
+				m.Processes[i].Turnaround, m.Processes[i].Exit = &turnaround, &exit
+			}
+		}
+		all = append(all, m)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(all)
+}
+
+// scheduleJSON is the JSON form of a schedule result printed by -format json; field order is the
+// output order. Processes holds a row of the schedule table each, and Gantt includes idle time as
+// IdlePID slices.
+type scheduleJSON struct {
+	Title           string        `json:"title"`
+	Partial         bool          `json:"partial"`
+	StoppedAt       int64         `json:"stopped_at"`
+	Processes       []processJSON `json:"processes"`
+	Gantt           []TimeSlice   `json:"gantt"`
+	AvgWait         float64       `json:"avg_wait"`
+	AvgTurnaround   float64       `json:"avg_turnaround"`
+	AvgResponse     float64       `json:"avg_response"`
+	Throughput      float64       `json:"throughput"`
+	Utilization     float64       `json:"utilization"`
+	ContextSwitches int           `json:"context_switches"`
+	Notes           []string      `json:"notes"`
+}
+
+// processJSON is a schedule table row in JSON, a field per column. A cell the table shows as "-",
+// such as the turnaround of a process that never exited, is null; the optional columns are left
+// out when the table doesn't have them, or when the process has no deadline for the deadline ones.
+type processJSON struct {
+	PID            int64    `json:"pid"`
+	Priority       float64  `json:"priority"`
+	Weight         *int64   `json:"weight,omitempty"`
+	Burst          int64    `json:"burst"`
+	Arrival        int64    `json:"arrival"`
+	Release        *int64   `json:"release,omitempty"`
+	Wait           int64    `json:"wait"`
+	Response       *int64   `json:"response"`
+	Turnaround     *int64   `json:"turnaround"`
+	NormTurnaround *float64 `json:"norm_turnaround"`
+	Exit           *int64   `json:"exit"`
+	Deadline       *int64   `json:"deadline,omitempty"`
+	Missed         *bool    `json:"missed,omitempty"`
+	Laxity         *int64   `json:"laxity,omitempty"`
+	Queue          *int64   `json:"queue,omitempty"`
+	Vruntime       *float64 `json:"vruntime,omitempty"`
+}
+
+// newProcessJSON parses a schedule table row, with the columns in header, back into its values.
+func newProcessJSON(header, row []string) (processJSON, error) {
+	var p processJSON
+	for i, column := range header {
+		cell := row[i]
+		if cell == "-" {
+			continue // no value: null, or left out for the optional columns
+		}
+		var err error
+		switch column {
+		case "ID":
+			p.PID, err = strconv.ParseInt(cell, 10, 64)
+		case "Priority":
+			p.Priority, err = strconv.ParseFloat(cell, 64)
+		case "Weight":
+			p.Weight, err = intCell(cell)
+		case "Burst":
+			p.Burst, err = strconv.ParseInt(cell, 10, 64)
+		case "Arrival":
+			p.Arrival, err = strconv.ParseInt(cell, 10, 64)
+		case "Release":
+			p.Release, err = intCell(cell)
+		case "Wait":
+			p.Wait, err = strconv.ParseInt(cell, 10, 64)
+		case "Response":
+			p.Response, err = intCell(cell)
+		case "Turnaround":
+			p.Turnaround, err = intCell(cell)
+		case "Norm.TA":
+			p.NormTurnaround, err = floatCell(cell)
+		case "Exit":
+			p.Exit, err = intCell(cell)
+		case "Deadline":
+			p.Deadline, err = intCell(cell)
+		case "Missed":
+			missed := cell == "true"
+			p.Missed = &missed
+		case "Laxity":
+			p.Laxity, err = intCell(strings.TrimSuffix(cell, " (unmeetable)"))
+		case "Queue":
+			p.Queue, err = intCell(cell)
+		case "Vruntime":
+			p.Vruntime, err = floatCell(cell)
+		}
+		if err != nil {
+			return p, fmt.Errorf("P%s: %s %q is not a number", row[0], column, cell)
+		}
+	}
+	return p, nil
+}
+
+func intCell(cell string) (*int64, error) {
+	v, err := strconv.ParseInt(cell, 10, 64)
+	return &v, err
+}
+
+func floatCell(cell string) (*float64, error) {
+	v, err := strconv.ParseFloat(cell, 64)
+	return &v, err
+}
+
+// writeResults renders every result at once in the formats that need a single document for the
+// whole run, json, csv and svg, and does nothing for the others, which the schedulers render themselves.
+func writeResults(w io.Writer, format string, results []ScheduleResult) error {
+	switch format {
+	case "json":
+		return writeResultsJSON(w, results)
+	case "csv":
+		return writeResultsCSV(w, results)
+	case "svg":
+		return writeResultsSVG(w, results)
+	}
+	return nil
+}
+
+// writeResultsJSON writes the results as a JSON array of schedules in algorithm order.
+func writeResultsJSON(w io.Writer, results []ScheduleResult) error {
+	all := make([]scheduleJSON, 0, len(results))
+	for _, res := range results {
+		s := scheduleJSON{
+			Title:           res.Title,
+			Partial:         res.Err != nil,
+			StoppedAt:       res.StoppedAt,
+			Processes:       make([]processJSON, len(res.Rows)),
+			Gantt:           withIdle(res.Gantt),
+			AvgWait:         res.AvgWait,
+			AvgTurnaround:   res.AvgTurnaround,
+			AvgResponse:     res.AvgResponse,
+			Throughput:      res.Throughput,
+			Utilization:     res.Utilization,
+			ContextSwitches: res.ContextSwitches,
+			Notes:           res.Notes,
+		}
+		for i, row := range res.Rows {
+			p, err := newProcessJSON(res.Header, row)
+			if err != nil {
+				return fmt.Errorf("%s: %w", res.Title, err)
+			}
+			s.Processes[i] = p
+		}
+		if s.Notes == nil {
+			s.Notes = []string{}
+		}
+		all = append(all, s)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(all)
+}
+
+// writeResultsCSV writes every result's schedule table with the algorithm as an extra first
+// column, under a single header row holding every column any of the tables has, in the order they
+// first appear. A schedule without a column, such as Queue outside MLFQ, leaves its cells empty.
+func writeResultsCSV(w io.Writer, results []ScheduleResult) error {
+	var header []string
+	for _, res := range results {
+		for _, column := range res.Header {
+			if !hasColumn(header, column) {
+				header = append(header, column)
+			}
+		}
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.Write(append([]string{"algorithm"}, header...)); err != nil {
+		return err
+	}
+	for _, res := range results {
+		for _, row := range res.Rows {
+			record := make([]string, 1+len(header))
+			record[0] = res.Title
+			for i, column := range res.Header {
+				for k, name := range header {
+					if name == column {
+						record[1+k] = row[i]
+					}
+				}
+			}
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeOutputFile creates the named file and fills it with write, naming what it holds in errors.
+func writeOutputFile(name, what string, write func(io.Writer) error) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return fmt.Errorf("%w: creating %s", err, what)
+	}
+	if err := write(f); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("%w: writing %s", err, what)
+	}
+	return f.Close()
+}
@@ -0,0 +1,278 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func Test_writeGanttCSV(t *testing.T) {
+	t.Parallel()
+	results := []ScheduleResult{
+		{Title: "First-come, first-serve", Gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 5}, {PID: 2, Start: 5, Stop: 14}}},
+		{Title: "Round-robin", Gantt: []TimeSlice{{PID: 1, Start: 2, Stop: 4}, {PID: 2, Start: 4, Stop: 4}, {PID: 2, Start: 6, Stop: 7}}},
+	}
+	want := `algorithm,pid,start,stop
+"First-come, first-serve",1,0,5
+"First-come, first-serve",2,5,14
+Round-robin,-1,0,2
+Round-robin,1,2,4
+Round-robin,-1,4,6
+Round-robin,2,6,7
+`
+	var w bytes.Buffer
+	if err := writeGanttCSV(&w, results); err != nil {
+		t.Fatal(err)
+	}
+	if got := w.String(); got != want {
+		t.Errorf("writeGanttCSV() = %v, want %v", got, want)
+	}
+}
+
+func Test_writeBurndownCSV(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: 1, BurstDuration: 3, Priority: 2}, {ProcessID: 2, ArrivalTime: 1, BurstDuration: 1, Priority: 1}}
+	// P2 preempts P1 at t=1, which then has to redo the tick it ran
+	res := PrioritySchedule(context.Background(), io.Discard, "Preemptive priority", processes, SchedulerOptions{PreemptPenalty: 1, Burndown: true})
+	want := `algorithm,time,pid,remaining
+Preemptive priority,0,1,3
+Preemptive priority,0,2,1
+Preemptive priority,1,1,3
+Preemptive priority,1,2,1
+Preemptive priority,2,1,3
+Preemptive priority,2,2,0
+Preemptive priority,3,1,2
+Preemptive priority,3,2,0
+Preemptive priority,4,1,1
+Preemptive priority,4,2,0
+Preemptive priority,5,1,0
+Preemptive priority,5,2,0
+`
+	var w bytes.Buffer
+	if err := writeBurndownCSV(&w, processes, []ScheduleResult{res}); err != nil {
+		t.Fatal(err)
+	}
+	if got := w.String(); got != want {
+		t.Errorf("writeBurndownCSV() = %v, want %v", got, want)
+	}
+}
+
+func TestSchedulersBurndown(t *testing.T) {
+	t.Parallel()
+	remaining := func(res ScheduleResult) []int64 {
+		var got []int64
+		for i, tick := range res.Burndown {
+			if tick.Time != int64(i) {
+				t.Errorf("Burndown[%d].Time = %d, want %d", i, tick.Time, i)
+			}
+			got = append(got, tick.Remaining[0])
+		}
+		return got
+	}
+	single := []Process{{ProcessID: 1, BurstDuration: 2}}
+
+	// the dispatcher overhead ticks don't work off any burst
+	res := RRSchedule(context.Background(), io.Discard, "Round-robin", single, SchedulerOptions{RROverhead: 0.5, Burndown: true})
+	if got, want := remaining(res), []int64{2, 2, 1, 1, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("round-robin with overhead burndown = %v, want %v", got, want)
+	}
+	// blocking on I/O is no preemption, so the penalty doesn't apply
+	withIO := []Process{{ProcessID: 1, BurstDuration: 4, Bursts: []Burst{{Duration: 2}, {Duration: 3, IO: true}, {Duration: 2}}}}
+	res = EDFSchedule(context.Background(), io.Discard, "Earliest-deadline-first", withIO, SchedulerOptions{PreemptPenalty: 1, Burndown: true})
+	if got, want := remaining(res), []int64{4, 3, 2, 2, 2, 2, 1, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("EDF with I/O burndown = %v, want %v", got, want)
+	}
+	res = FCFSSchedule(context.Background(), io.Discard, "First-come, first-serve", append(single, Process{ProcessID: 2, ArrivalTime: 3, BurstDuration: 1}), SchedulerOptions{Burndown: true})
+	if got, want := remaining(res), []int64{2, 1, 0, 0, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FCFS burndown = %v, want %v", got, want)
+	}
+	if res := FCFSSchedule(context.Background(), io.Discard, "First-come, first-serve", single, SchedulerOptions{}); res.Burndown != nil {
+		t.Errorf("Burndown = %v without SchedulerOptions.Burndown, want none", res.Burndown)
+	}
+}
+
+func Test_writeMetricsJSON(t *testing.T) {
+	t.Parallel()
+	// P8 is still waiting when the schedule stops, so it has no turnaround or exit yet
+	processes := []Process{{ProcessID: 7, ArrivalTime: 0, BurstDuration: 2}, {ProcessID: 8, ArrivalTime: 1, BurstDuration: 3}}
+	results := []ScheduleResult{{
+		Title:         "Round-robin",
+		Gantt:         []TimeSlice{{PID: 7, Start: 0, Stop: 2}},
+		Data:          []ProcessData{{TAround: 2, ExitTime: 2}, {TotalWait: 1, FirstRun: -1, Remaining: 3}},
+		AvgTurnaround: 2,
+		Throughput:    0.5,
+		Utilization:   1,
+		FairnessIndex: 1,
+		StoppedAt:     2,
+		MakespanGap:   MakespanGap{Makespan: 2, Busy: 2, LowerBound: 2},
+	}}
+	want := `[
+  {
+    "algorithm": "Round-robin",
+    "partial": false,
+    "stopped_at": 2,
+    "completed": 1,
+    "avg_wait": 0,
+    "avg_turnaround": 2,
+    "throughput": 0.5,
+    "utilization": 1,
+    "avg_response": 0,
+    "weighted_response": 0,
+    "context_switches": 0,
+    "fairness_index": 1,
+    "lost_work": 0,
+    "makespan": {
+      "makespan": 2,
+      "busy": 2,
+      "lower_bound": 2,
+      "forced_idle": 0
+    },
+    "processes": [
+      {
+        "pid": 7,
+        "wait": 0,
+        "turnaround": 2,
+        "exit": 2,
+        "first_run": 0,
+        "lost_work": 0,
+        "remaining": 0
+      },
+      {
+        "pid": 8,
+        "wait": 1,
+        "turnaround": null,
+        "exit": null,
+        "first_run": -1,
+        "lost_work": 0,
+        "remaining": 3
+      }
+    ]
+  }
+]
+`
+	var w bytes.Buffer
+	if err := writeMetricsJSON(&w, processes, results); err != nil {
+		t.Fatal(err)
+	}
+	if got := w.String(); got != want {
+		t.Errorf("writeMetricsJSON() = %v, want %v", got, want)
+	}
+}
+
+func Test_writeResults(t *testing.T) {
+	t.Parallel()
+	results := []ScheduleResult{{
+		Title:         "Round-robin",
+		Header:        []string{"ID", "Burst", "Turnaround", "Exit"},
+		Rows:          [][]string{{"7", "2", "3", "3"}, {"8", "1", "-", "-"}},
+		Gantt:         []TimeSlice{{PID: 7, Start: 1, Stop: 3}},
+		AvgTurnaround: 2,
+		Throughput:    0.5,
+		Utilization:   2.0 / 3,
+		StoppedAt:     3,
+	}, {
+		Title:  "Multilevel feedback queue",
+		Header: []string{"ID", "Burst", "Exit", "Queue"},
+		Rows:   [][]string{{"7", "2", "2", "1"}},
+	}}
+	wantJSON := `[
+  {
+    "title": "Round-robin",
+    "partial": false,
+    "stopped_at": 3,
+    "processes": [
+      {
+        "pid": 7,
+        "priority": 0,
+        "burst": 2,
+        "arrival": 0,
+        "wait": 0,
+        "response": null,
+        "turnaround": 3,
+        "norm_turnaround": null,
+        "exit": 3
+      },
+      {
+        "pid": 8,
+        "priority": 0,
+        "burst": 1,
+        "arrival": 0,
+        "wait": 0,
+        "response": null,
+        "turnaround": null,
+        "norm_turnaround": null,
+        "exit": null
+      }
+    ],
+    "gantt": [
+      {
+        "pid": -1,
+        "start": 0,
+        "stop": 1
+      },
+      {
+        "pid": 7,
+        "start": 1,
+        "stop": 3
+      }
+    ],
+    "avg_wait": 0,
+    "avg_turnaround": 2,
+    "avg_response": 0,
+    "throughput": 0.5,
+    "utilization": 0.6666666666666666,
+    "context_switches": 0,
+    "notes": []
+  },
+  {
+    "title": "Multilevel feedback queue",
+    "partial": false,
+    "stopped_at": 0,
+    "processes": [
+      {
+        "pid": 7,
+        "priority": 0,
+        "burst": 2,
+        "arrival": 0,
+        "wait": 0,
+        "response": null,
+        "turnaround": null,
+        "norm_turnaround": null,
+        "exit": 2,
+        "queue": 1
+      }
+    ],
+    "gantt": [],
+    "avg_wait": 0,
+    "avg_turnaround": 0,
+    "avg_response": 0,
+    "throughput": 0,
+    "utilization": 0,
+    "context_switches": 0,
+    "notes": []
+  }
+]
+`
+	// one header for both tables, with the cells of the columns a table lacks left empty
+	wantCSV := `algorithm,ID,Burst,Turnaround,Exit,Queue
+Round-robin,7,2,3,3,
+Round-robin,8,1,-,-,
+Multilevel feedback queue,7,2,,2,1
+`
+	for format, want := range map[string]string{"json": wantJSON, "csv": wantCSV, "table": ""} {
+		var w bytes.Buffer
+		if err := writeResults(&w, format, results); err != nil {
+			t.Fatal(err)
+		}
+		if got := w.String(); got != want {
+			t.Errorf("writeResults(%q) = %v, want %v", format, got, want)
+		}
+	}
+
+	results[0].Rows[0][1] = "two"
+	if err := writeResults(io.Discard, "json", results); err == nil || err.Error() != `Round-robin: P7: Burst "two" is not a number` {
+		t.Errorf("writeResults(json) with a malformed cell = %v, want it named", err)
+	}
+}
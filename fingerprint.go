@@ -0,0 +1,49 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+)
+
+// Fingerprint returns a stable SHA-256 hex digest of the schedule of processes in res, so two
+// schedules can be compared without diffing their output. The digest covers this canonical
+// serialization, one item per line, each terminated by "\n":
+//
+//	title <Title>
+//	slice <PID> <Start> <Stop>         for every Gantt slice, in order
+//	process <PID> <Arrival> <Burst> <Wait> <Turnaround> <Exit> <Response> <LostWork>
+//	                                   for every process, in input order
+//	metrics <AvgWait> <AvgTurnaround> <Throughput> <AvgResponse> <WeightedResponse>
+//	lost <LostWork>
+//
+// where the metrics are printed with %.6f, so rounding noise below that can't change a
+// fingerprint, and a process that never ran has the response "-". Only simulated values go in,
+// not the table rows, so the notes and rendering options such as the columns shown or
+// SchedulerOptions.ShowWeight leave the fingerprint unchanged.
+func Fingerprint(processes []Process, res ScheduleResult) string {
+	h := sha256.New()
+	writeCanonical(h, processes, res)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// writeCanonical writes the serialization Fingerprint hashes.
+func writeCanonical(w io.Writer, processes []Process, res ScheduleResult) {
+	_, _ = fmt.Fprintf(w, "title %s\n", res.Title)
+	for _, slice := range res.Gantt {
+		_, _ = fmt.Fprintf(w, "slice %d %d %d\n", slice.PID, slice.Start, slice.Stop)
+	}
+	for i, proc := range res.Data {
+		p := processes[i]
+		response := "-"
+		if proc.FirstRun >= 0 {
+			response = fmt.Sprint(proc.FirstRun - p.ArrivalTime)
+		}
+		_, _ = fmt.Fprintf(w, "process %d %d %d %d %d %d %s %d\n", p.ProcessID, p.ArrivalTime, p.BurstDuration,
+			proc.TotalWait, proc.TAround, proc.ExitTime, response, proc.LostWork)
+	}
+	_, _ = fmt.Fprintf(w, "metrics %.6f %.6f %.6f %.6f %.6f\n",
+		res.AvgWait, res.AvgTurnaround, res.Throughput, res.AvgResponse, res.WeightedResponse)
+	_, _ = fmt.Fprintf(w, "lost %d\n", res.LostWork)
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestFingerprint(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+	}
+	seen := make(map[string]string)
+	for _, tt := range testSchedulers {
+		first := tt.schedule(context.Background(), io.Discard, tt.name, processes, SchedulerOptions{})
+		second := tt.schedule(context.Background(), io.Discard, tt.name, processes, SchedulerOptions{})
+		fp := Fingerprint(processes, first)
+		if got := Fingerprint(processes, second); got != fp {
+			t.Errorf("%s: fingerprints of two runs differ: %s and %s", tt.name, fp, got)
+		}
+		if other, ok := seen[fp]; ok {
+			t.Errorf("%s: fingerprint collides with %s", tt.name, other)
+		}
+		seen[fp] = tt.name
+	}
+
+	// rendering options change the table rows but not the fingerprint
+	plain := RRSchedule(context.Background(), io.Discard, "Round-robin", processes, SchedulerOptions{})
+	rendered := RRSchedule(context.Background(), io.Discard, "Round-robin", processes, SchedulerOptions{ShowWeight: true, Columns: []string{"id", "burst"}})
+	if reflect.DeepEqual(plain.Rows, rendered.Rows) {
+		t.Fatalf("Rows = %v with the rendering options, want them to differ", rendered.Rows)
+	}
+	if got, want := Fingerprint(processes, rendered), Fingerprint(processes, plain); got != want {
+		t.Errorf("fingerprint with rendering options = %s, want %s", got, want)
+	}
+
+	res := ScheduleResult{
+		Title:         "Round-robin",
+		Gantt:         []TimeSlice{{PID: 1, Start: 0, Stop: 2}},
+		Rows:          [][]string{{"1", "0", "2"}},
+		Data:          []ProcessData{{TAround: 2, ExitTime: 2, FirstRun: 0}, {FirstRun: -1}},
+		AvgTurnaround: 2,
+		Throughput:    0.5,
+	}
+	want := "title Round-robin\nslice 1 0 2\nprocess 1 0 2 0 2 2 0 0\nprocess 2 3 9 0 0 0 - 0\nmetrics 0.000000 2.000000 0.500000 0.000000 0.000000\nlost 0\n"
+	var w bytes.Buffer
+	writeCanonical(&w, []Process{{ProcessID: 1, BurstDuration: 2}, {ProcessID: 2, ArrivalTime: 3, BurstDuration: 9}}, res)
+	if got := w.String(); got != want {
+		t.Errorf("writeCanonical() = %q, want %q", got, want)
+	}
+}
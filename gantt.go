@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// defaultTerminalWidth is the wrap width when $COLUMNS does not give one.
+const defaultTerminalWidth = 80
+
+// terminalWidth returns the width to wrap wide charts at, taken from $COLUMNS when set.
+func terminalWidth() int {
+	if cols, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && cols > 0 {
+		return cols
+	}
+	return defaultTerminalWidth
+}
+
+// RenderProportionalGantt draws the Gantt chart so that every time unit takes scale characters:
+// each slice starts with a "|" and its PID and is filled with "-" up to its stop, and idle gaps
+// are left blank. The time of every slice boundary is printed under it, and the chart wraps at
+// the terminal width.
+func RenderProportionalGantt(w io.Writer, gantt []TimeSlice, scale int) {
+	renderProportionalGantt(w, gantt, scale, terminalWidth())
+}
+
+func renderProportionalGantt(w io.Writer, gantt []TimeSlice, scale, width int) {
+	if scale < 1 {
+		scale = 1
+	}
+	var end int64
+	for _, slice := range gantt {
+		if slice.Stop > end {
+			end = slice.Stop
+		}
+	}
+
+	bar := []byte(strings.Repeat(" ", int(end)*scale))
+	for _, slice := range gantt {
+		label := "|" + fmt.Sprint(slice.PID)
+		from, to := int(slice.Start)*scale, int(slice.Stop)*scale
+		for col := from; col < to; col++ {
+			if k := col - from; k < len(label) {
+				bar[col] = label[k]
+			} else {
+				bar[col] = '-'
+			}
+		}
+	}
+
+	// the axis may run past the bar by the width of the last label
+	axis := []byte(strings.Repeat(" ", len(bar)+len(fmt.Sprint(end))))
+	free := 0 // first axis column not taken by a label
+	mark := func(t int64) {
+		col, label := int(t)*scale, fmt.Sprint(t)
+		if col < free {
+			return // no room next to the previous label
+		}
+		copy(axis[col:], label)
+		free = col + len(label) + 1
+	}
+	for _, slice := range gantt {
+		mark(slice.Start)
+		mark(slice.Stop)
+	}
+	axis = bytes.TrimRight(axis, " ")
+
+	_, _ = fmt.Fprintln(w, "Gantt schedule")
+	if width < 1 {
+		width = defaultTerminalWidth
+	}
+	for off := 0; off < len(bar) || off < len(axis); off += width {
+		_, _ = fmt.Fprintln(w, strings.TrimRight(string(chunk(bar, off, width)), " "))
+		_, _ = fmt.Fprintln(w, strings.TrimRight(string(chunk(axis, off, width)), " "))
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+// chunk returns up to width bytes of b starting at off.
+func chunk(b []byte, off, width int) []byte {
+	if off >= len(b) {
+		return nil
+	}
+	if off+width > len(b) {
+		return b[off:]
+	}
+	return b[off : off+width]
+}
+
+// miniGanttBlocks are the characters RenderMiniGantt gives processes, by ascending PID; they are
+// reused when there are more processes than blocks.
+var miniGanttBlocks = []rune("█▓▒░▚▞▙▟▛▜▀▄▌▐")
+
+// miniGanttIdle marks the time units in which no process ran.
+const miniGanttIdle = '·'
+
+// RenderMiniGantt draws the Gantt chart on one line, one character per time unit, each process
+// in its own shade of block, followed by a legend of which block is which process.
+func RenderMiniGantt(w io.Writer, gantt []TimeSlice) {
+	blocks := miniGanttSymbols(gantt)
+	_, _ = fmt.Fprintln(w, miniGanttLine(gantt, blocks))
+	_, _ = fmt.Fprintln(w, miniGanttLegend(blocks))
+}
+
+// miniGanttSymbols assigns a block to every PID in the charts, so stacked charts share a legend.
+func miniGanttSymbols(gantts ...[]TimeSlice) map[int64]rune {
+	var pids []int64
+	seen := make(map[int64]bool)
+	for _, gantt := range gantts {
+		for _, slice := range gantt {
+			if !seen[slice.PID] {
+				seen[slice.PID] = true
+				pids = append(pids, slice.PID)
+			}
+		}
+	}
+	sort.Slice(pids, func(i, j int) bool { return pids[i] < pids[j] })
+
+	blocks := make(map[int64]rune, len(pids))
+	for i, pid := range pids {
+		blocks[pid] = miniGanttBlocks[i%len(miniGanttBlocks)]
+	}
+	return blocks
+}
+
+// miniGanttLine renders one chart with the given blocks, idle time as miniGanttIdle.
+func miniGanttLine(gantt []TimeSlice, blocks map[int64]rune) string {
+	var end int64
+	for _, slice := range gantt {
+		if slice.Stop > end {
+			end = slice.Stop
+		}
+	}
+	line := []rune(strings.Repeat(string(miniGanttIdle), int(end)))
+	for _, slice := range gantt {
+		for t := slice.Start; t < slice.Stop; t++ {
+			line[t] = blocks[slice.PID]
+		}
+	}
+	return string(line)
+}
+
+// miniGanttLegend lists the blocks by ascending PID, then the idle mark.
+func miniGanttLegend(blocks map[int64]rune) string {
+	pids := make([]int64, 0, len(blocks))
+	for pid := range blocks {
+		pids = append(pids, pid)
+	}
+	sort.Slice(pids, func(i, j int) bool { return pids[i] < pids[j] })
+
+	entries := make([]string, 0, len(pids)+1)
+	for _, pid := range pids {
+		entries = append(entries, fmt.Sprintf("%cP%d", blocks[pid], pid))
+	}
+	entries = append(entries, string(miniGanttIdle)+"idle")
+	return strings.Join(entries, " ")
+}
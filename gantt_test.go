@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_renderProportionalGantt(t *testing.T) {
+	t.Parallel()
+	gantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 3},
+		{PID: 2, Start: 3, Stop: 4},
+		{PID: 3, Start: 6, Stop: 8},
+	}
+	tests := []struct {
+		name  string
+		scale int
+		width int
+		want  string
+	}{
+		{
+			name:  "one character per unit",
+			scale: 1,
+			width: 80,
+			want: `Gantt schedule
+|1-|  |3
+0  3  6 8
+
+`,
+		},
+		{
+			name:  "scaled",
+			scale: 2,
+			width: 80,
+			want: `Gantt schedule
+|1----|2    |3--
+0     3 4   6   8
+
+`,
+		},
+		{
+			name:  "wrapped",
+			scale: 2,
+			width: 8,
+			want: `Gantt schedule
+|1----|2
+0     3
+    |3--
+4   6
+` + `
+8
+
+`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			renderProportionalGantt(&w, gantt, tt.scale, tt.width)
+			if got := w.String(); got != tt.want {
+				t.Errorf("renderProportionalGantt() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderMiniGantt(t *testing.T) {
+	t.Parallel()
+	gantt := []TimeSlice{
+		{PID: 2, Start: 0, Stop: 3},
+		{PID: 1, Start: 3, Stop: 5},
+		{PID: 2, Start: 7, Stop: 8},
+	}
+	want := "▓▓▓██··▓\n█P1 ▓P2 ·idle\n"
+	var w bytes.Buffer
+	RenderMiniGantt(&w, gantt)
+	if got := w.String(); got != want {
+		t.Errorf("RenderMiniGantt() = %q, want %q", got, want)
+	}
+}
+
+func Test_outputGantt(t *testing.T) {
+	t.Parallel()
+	// an idle gap before the first slice and between two, a PID too long for a cell, and times
+	// of up to three digits, which must all start at the border they fall on
+	gantt := []TimeSlice{
+		{PID: 1, Start: 2, Stop: 5},
+		{PID: 12, Start: 5, Stop: 17},
+		{PID: 1234567890, Start: 20, Stop: 120},
+		{PID: 3, Start: 120, Stop: 121},
+	}
+	var w bytes.Buffer
+	outputGantt(&w, gantt)
+	if got, want := w.String(), loadFixture(t, "gantt_test.txt"); got != want {
+		t.Errorf("outputGantt() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// GenerateProcesses returns n random processes with bursts in [1, 20], arrivals in [0, n] and
+// priorities in [1, 10]. The same seed always yields the same processes, which are numbered from
+// 1 in arrival order.
+func GenerateProcesses(n int, seed int64) []Process {
+	rng := rand.New(rand.NewSource(seed))
+	processes := make([]Process, n)
+	for i := range processes {
+		processes[i] = Process{
+			BurstDuration: 1 + rng.Int63n(20),
+			ArrivalTime:   rng.Int63n(int64(n) + 1),
+			Priority:      float64(1 + rng.Int63n(10)),
+			Weight:        1,
+		}
+	}
+
+	sort.SliceStable(processes, func(i, j int) bool {
+		return processes[i].ArrivalTime < processes[j].ArrivalTime
+	})
+	for i := range processes {
+		processes[i].ProcessID = int64(i + 1)
+	}
+	return processes
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestGenerateProcesses(t *testing.T) {
+	t.Parallel()
+	const n = 50
+	processes := GenerateProcesses(n, 42)
+	if len(processes) != n {
+		t.Fatalf("GenerateProcesses() returned %d processes, want %d", len(processes), n)
+	}
+	if again := GenerateProcesses(n, 42); !reflect.DeepEqual(processes, again) {
+		t.Error("GenerateProcesses() is not reproducible for the same seed")
+	}
+	if err := validateProcesses(processes); err != nil {
+		t.Errorf("generated processes are invalid: %v", err)
+	}
+	for i, p := range processes {
+		if p.ProcessID != int64(i+1) {
+			t.Errorf("processes[%d].ProcessID = %d, want %d", i, p.ProcessID, i+1)
+		}
+		if p.BurstDuration < 1 || p.BurstDuration > 20 || p.ArrivalTime < 0 || p.ArrivalTime > n || p.Priority < 1 || p.Priority > 10 {
+			t.Errorf("processes[%d] = %+v is out of range", i, p)
+		}
+		if i > 0 && p.ArrivalTime < processes[i-1].ArrivalTime {
+			t.Errorf("processes[%d] arrives before processes[%d]", i, i-1)
+		}
+	}
+
+	// the generated CSV loads back to the same processes
+	var w bytes.Buffer
+	if err := writeProcesses(&w, processes); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := loadProcesses(&w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded, processes) {
+		t.Errorf("loadProcesses(writeProcesses()) = %v, want %v", loaded, processes)
+	}
+}
@@ -1,6 +1,6 @@
 module scheduler
 
-go 1.20
+go 1.21
 
 require github.com/olekukonko/tablewriter v0.0.5
 
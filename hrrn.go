@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"io"
+)
+
+// HRRNSchedule outputs a non-preemptive highest-response-ratio-next schedule: whenever the CPU is
+// free, the released, unfinished process with the highest response ratio (wait + burst) / burst
+// runs to completion, ties broken by arrival then PID. A short process still goes first, but a
+// long one's ratio grows while it waits, so it can't be starved as under NonPreemptiveSJFSchedule.
+func HRRNSchedule(ctx context.Context, w io.Writer, title string, processes []Process, opts SchedulerOptions) ScheduleResult {
+	s := runTicks(ctx, title, processes, opts, tickPolicy{next: func(s *tickSim) int {
+		if s.current >= 0 {
+			return s.current // only a free CPU picks the next process
+		}
+		// higherRatio compares the response ratios of i and j without dividing: (wi + bi) / bi is
+		// above (wj + bj) / bj when (wi + bi) × bj is above (wj + bj) × bi.
+		higherRatio := func(i, j int) (higher, equal bool) {
+			bi, bj := processes[i].BurstDuration, processes[j].BurstDuration
+			ri, rj := (s.pd[i].TotalWait+bi)*bj, (s.pd[j].TotalWait+bj)*bi
+			return ri > rj, ri == rj
+		}
+		next := -1
+		for i, p := range processes {
+			if !s.ready(i) {
+				continue
+			}
+			if next < 0 {
+				next = i
+				continue
+			}
+			higher, equal := higherRatio(i, next)
+			if higher || (equal && p.ArrivalTime < processes[next].ArrivalTime) ||
+				(equal && p.ArrivalTime == processes[next].ArrivalTime && p.ProcessID < processes[next].ProcessID) {
+				next = i
+			}
+		}
+		return next
+	}})
+
+	res := s.result(title)
+	outputResult(w, opts, res)
+	return res
+}
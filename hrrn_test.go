@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestHRRNSchedule(t *testing.T) {
+	t.Parallel()
+	// a long job followed by a stream of short ones, each arriving while the previous one runs
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 6},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 2},
+		{ProcessID: 4, ArrivalTime: 4, BurstDuration: 2},
+		{ProcessID: 5, ArrivalTime: 6, BurstDuration: 2},
+	}
+	// at t=3 P3's ratio (1+2)/2 beats P2's (2+6)/6, but at t=5 P2's (4+6)/6 beats P4's (1+2)/2
+	want := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 3},
+		{PID: 3, Start: 3, Stop: 5},
+		{PID: 2, Start: 5, Stop: 11},
+		{PID: 4, Start: 11, Stop: 13},
+		{PID: 5, Start: 13, Stop: 15},
+	}
+	res := HRRNSchedule(context.Background(), io.Discard, "Highest-response-ratio-next", processes, SchedulerOptions{})
+	if !reflect.DeepEqual(res.Gantt, want) {
+		t.Errorf("Gantt = %v, want %v", res.Gantt, want)
+	}
+
+	// shortest-job-first keeps passing P2 over for every short job that arrived meanwhile
+	wantSJF := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 3},
+		{PID: 3, Start: 3, Stop: 5},
+		{PID: 4, Start: 5, Stop: 7},
+		{PID: 5, Start: 7, Stop: 9},
+		{PID: 2, Start: 9, Stop: 15},
+	}
+	sjf := NonPreemptiveSJFSchedule(context.Background(), io.Discard, "Shortest-job-first", processes, SchedulerOptions{})
+	if !reflect.DeepEqual(sjf.Gantt, wantSJF) {
+		t.Errorf("SJF Gantt = %v, want %v", sjf.Gantt, wantSJF)
+	}
+	if got, sjfWait := res.Data[1].TotalWait, sjf.Data[1].TotalWait; got != 4 || sjfWait != 8 {
+		t.Errorf("P2 wait = %d under HRRN and %d under SJF, want 4 and 8", got, sjfWait)
+	}
+}
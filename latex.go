@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// latexScale is how many centimetres a time unit takes in the TikZ Gantt chart.
+const latexScale = 0.5
+
+// latexEscaper escapes the characters LaTeX treats specially in text.
+var latexEscaper = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`&`, `\&`,
+	`%`, `\%`,
+	`$`, `\$`,
+	`#`, `\#`,
+	`_`, `\_`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`~`, `\textasciitilde{}`,
+	`^`, `\textasciicircum{}`,
+)
+
+// outputLaTeX renders a schedule result as LaTeX for reports: a subsection titled after the
+// algorithm holding the Gantt chart as a TikZ picture, the schedule table as a tabular and any
+// notes as a list. The Gantt chart needs \usepackage{tikz}.
+func outputLaTeX(w io.Writer, res ScheduleResult) {
+	_, _ = fmt.Fprintf(w, "\\subsection*{%s}\n", latexEscaper.Replace(res.Title))
+	outputLaTeXGantt(w, res.Gantt)
+	outputLaTeXTable(w, res)
+	if len(res.Notes) > 0 {
+		_, _ = fmt.Fprintln(w, `\begin{itemize}`)
+		for _, note := range res.Notes {
+			_, _ = fmt.Fprintf(w, "\\item %s\n", latexEscaper.Replace(note))
+		}
+		_, _ = fmt.Fprintln(w, `\end{itemize}`)
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+// outputLaTeXGantt draws each slice as a labelled box on one row, with the time under every
+// slice boundary; idle time stays blank.
+func outputLaTeXGantt(w io.Writer, gantt []TimeSlice) {
+	_, _ = fmt.Fprintf(w, "\\begin{tikzpicture}[x=%gcm, y=0.8cm]\n", latexScale)
+	labelled := make(map[int64]bool)
+	for _, slice := range gantt {
+		if slice.Start == slice.Stop {
+			continue // nothing ran
+		}
+		_, _ = fmt.Fprintf(w, "\\draw (%d,0) rectangle node {P%d} (%d,1);\n", slice.Start, slice.PID, slice.Stop)
+		for _, t := range []int64{slice.Start, slice.Stop} {
+			if !labelled[t] {
+				_, _ = fmt.Fprintf(w, "\\node[below] at (%d,0) {%d};\n", t, t)
+				labelled[t] = true
+			}
+		}
+	}
+	_, _ = fmt.Fprintln(w, `\end{tikzpicture}`)
+	_, _ = fmt.Fprintln(w) // a paragraph break puts the table under the chart
+}
+
+// outputLaTeXTable writes the schedule table with the averages and throughput as its last row,
+// under the wait, turnaround, normalized turnaround and exit columns like the text table's footer.
+func outputLaTeXTable(w io.Writer, res ScheduleResult) {
+	cells := func(row []string) string {
+		escaped := make([]string, len(row))
+		for i, cell := range row {
+			escaped[i] = latexEscaper.Replace(cell)
+		}
+		return strings.Join(escaped, " & ") + ` \\`
+	}
+
+	_, _ = fmt.Fprintf(w, "\\begin{tabular}{%s}\n", strings.Repeat("r", len(res.Header)))
+	_, _ = fmt.Fprintln(w, `\hline`)
+	_, _ = fmt.Fprintln(w, cells(res.Header))
+	_, _ = fmt.Fprintln(w, `\hline`)
+	for _, row := range res.Rows {
+		_, _ = fmt.Fprintln(w, cells(row))
+	}
+	_, _ = fmt.Fprintln(w, `\hline`)
+	footer := make([]string, len(res.Header))
+	first := len(res.Header) // the label spans the columns before the first summary
+	for i, column := range res.Header {
+		switch column {
+		case "Wait":
+			footer[i] = fmt.Sprintf("%.2f", res.AvgWait)
+		case "Turnaround":
+			footer[i] = fmt.Sprintf("%.2f", res.AvgTurnaround)
+		case "Norm.TA":
+			footer[i] = fmt.Sprintf("%.2f", res.AvgNormTurnaround)
+		case "Exit":
+			footer[i] = fmt.Sprintf("%.2f/t", res.Throughput)
+		default:
+			continue
+		}
+		if i < first {
+			first = i
+		}
+	}
+	if first > 0 && first < len(res.Header) {
+		_, _ = fmt.Fprintf(w, "\\multicolumn{%d}{r}{Average wait, turnaround and throughput} & %s\n", first, cells(footer[first:]))
+	}
+	_, _ = fmt.Fprintln(w, `\hline`)
+	_, _ = fmt.Fprintln(w, `\end{tabular}`)
+}
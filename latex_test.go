@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_outputLaTeX(t *testing.T) {
+	t.Parallel()
+	res := ScheduleResult{
+		Title:         "R&D_queue",
+		Header:        []string{"ID", "Priority", "Burst", "Arrival", "Wait", "Turnaround", "Exit"},
+		Rows:          [][]string{{"1", "2", "3", "0", "0", "3", "3"}, {"2", "1", "2", "4", "0", "2", "6"}},
+		Gantt:         []TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 4, Stop: 6}},
+		AvgWait:       0,
+		AvgTurnaround: 2.5,
+		Throughput:    1.0 / 3,
+		Notes:         []string{"50% of {P2} waited"},
+	}
+	want := `\subsection*{R\&D\_queue}
+\begin{tikzpicture}[x=0.5cm, y=0.8cm]
+\draw (0,0) rectangle node {P1} (3,1);
+\node[below] at (0,0) {0};
+\node[below] at (3,0) {3};
+\draw (4,0) rectangle node {P2} (6,1);
+\node[below] at (4,0) {4};
+\node[below] at (6,0) {6};
+\end{tikzpicture}
+
+\begin{tabular}{rrrrrrr}
+\hline
+ID & Priority & Burst & Arrival & Wait & Turnaround & Exit \\
+\hline
+1 & 2 & 3 & 0 & 0 & 3 & 3 \\
+2 & 1 & 2 & 4 & 0 & 2 & 6 \\
+\hline
+\multicolumn{4}{r}{Average wait, turnaround and throughput} & 0.00 & 2.50 & 0.33/t \\
+\hline
+\end{tabular}
+\begin{itemize}
+\item 50\% of \{P2\} waited
+\end{itemize}
+
+`
+	var w bytes.Buffer
+	outputLaTeX(&w, res)
+	if got := w.String(); got != want {
+		t.Errorf("outputLaTeX() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// littleTolerance is the relative difference between the two sides of Little's law above which
+// the check warns.
+const littleTolerance = 0.05
+
+// LittlesLaw cross-checks a complete schedule's metrics with Little's law, L = λW: the
+// time-average number of processes in the system should equal the throughput times the average
+// turnaround.
+type LittlesLaw struct {
+	// InSystem integrates the number of arrived, unfinished processes over the schedule and
+	// divides by its length.
+	InSystem float64
+	// Predicted is Throughput × AvgTurnaround.
+	Predicted float64
+}
+
+// littlesLaw measures both sides of Little's law over the schedule from t=0 until the last exit.
+// It is zero unless every process exited.
+func littlesLaw(processes []Process, res ScheduleResult) LittlesLaw {
+	var end, area int64
+	for i, proc := range res.Data {
+		if proc.ExitTime == 0 {
+			return LittlesLaw{}
+		}
+		if proc.ExitTime > end {
+			end = proc.ExitTime
+		}
+		area += proc.ExitTime - processes[i].ArrivalTime
+	}
+	if end <= 0 {
+		return LittlesLaw{}
+	}
+	return LittlesLaw{
+		InSystem:  float64(area) / float64(end),
+		Predicted: res.Throughput * res.AvgTurnaround,
+	}
+}
+
+// Discrepancy is the difference between the two sides relative to the measured one.
+func (l LittlesLaw) Discrepancy() float64 {
+	if l.InSystem == 0 {
+		return 0
+	}
+	return math.Abs(l.Predicted-l.InSystem) / l.InSystem
+}
+
+// String reports both sides, warning if they differ by more than littleTolerance.
+func (l LittlesLaw) String() string {
+	s := fmt.Sprintf("Little's law: %.2f processes in the system on average, throughput × average turnaround = %.2f",
+		l.InSystem, l.Predicted)
+	if d := l.Discrepancy(); d > littleTolerance {
+		s += fmt.Sprintf(" (warning: they differ by %.0f%%)", d*100)
+	}
+	return s
+}
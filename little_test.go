@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"io"
+	"math"
+	"strings"
+	"testing"
+)
+
+func Test_littlesLaw(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 1, Priority: 3},
+	}
+	res := PrioritySchedule(context.Background(), io.Discard, "Preemptive priority", processes, SchedulerOptions{})
+	// turnarounds 7, 2 and 6 over a schedule of 8 ticks
+	if got := res.Little; math.Abs(got.InSystem-15.0/8) > 1e-9 || math.Abs(got.Predicted-got.InSystem) > 1e-9 {
+		t.Errorf("Little = %+v, want both sides %v", got, 15.0/8)
+	}
+	if strings.Contains(res.Little.String(), "warning") {
+		t.Errorf("String() = %q, want no warning", res.Little)
+	}
+
+	off := LittlesLaw{InSystem: 2, Predicted: 1.8}
+	if want := "(warning: they differ by 10%)"; !strings.HasSuffix(off.String(), want) {
+		t.Errorf("String() = %q, want it to end in %q", off, want)
+	}
+
+	res = PrioritySchedule(context.Background(), io.Discard, "Preemptive priority", processes, SchedulerOptions{Horizon: 4})
+	if res.Little != (LittlesLaw{}) {
+		t.Errorf("Little of a partial schedule = %+v, want none", res.Little)
+	}
+}
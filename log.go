@@ -0,0 +1,23 @@
+package main
+
+import (
+	"io"
+	"log/slog"
+)
+
+// logLevel is the minimum level of the logger main installs, set by every command's -log-level flag.
+var logLevel slog.LevelVar
+
+// newLogger returns a logger writing leveled key=value lines to w from the given level up. The
+// lines have no timestamp, so the diagnostics of two runs can be compared.
+func newLogger(w io.Writer, level slog.Leveler) *slog.Logger {
+	return slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{
+		Level: level,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+}
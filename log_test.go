@@ -0,0 +1,31 @@
+package main
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+)
+
+func Test_newLogger(t *testing.T) {
+	t.Parallel()
+	var (
+		w     bytes.Buffer
+		level slog.LevelVar
+	)
+	level.Set(slog.LevelWarn)
+	logger := newLogger(&w, &level)
+	logger.Info("loaded processes", "count", 3)
+	logger.Warn("CPU idle", "from", 2, "to", 5)
+	if want := "level=WARN msg=\"CPU idle\" from=2 to=5\n"; w.String() != want {
+		t.Errorf("logged %q, want %q", w.String(), want)
+	}
+
+	w.Reset()
+	if err := level.UnmarshalText([]byte("debug")); err != nil {
+		t.Fatal(err)
+	}
+	logger.Debug("ran", "pid", 1)
+	if want := "level=DEBUG msg=ran pid=1\n"; w.String() != want {
+		t.Errorf("logged %q after lowering the level, want %q", w.String(), want)
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"io"
+)
+
+// LRTFSchedule outputs a longest-remaining-time-first schedule, SRTFSchedule with the selection
+// inverted: every tick the released, unfinished process with the longest remaining burst runs,
+// ties broken by arrival then PID. It is a deliberately bad policy, useful to show how long the
+// average wait can get, as every process is held back until the others have caught up with it.
+func LRTFSchedule(ctx context.Context, w io.Writer, title string, processes []Process, opts SchedulerOptions) ScheduleResult {
+	s := runTicks(ctx, title, processes, opts, tickPolicy{next: func(s *tickSim) int {
+		next := -1
+		for i, p := range processes {
+			if !s.ready(i) {
+				continue
+			}
+			if next < 0 {
+				next = i
+				continue
+			}
+			q := processes[next]
+			if s.remaining[i] > s.remaining[next] ||
+				(s.remaining[i] == s.remaining[next] && p.ArrivalTime < q.ArrivalTime) ||
+				(s.remaining[i] == s.remaining[next] && p.ArrivalTime == q.ArrivalTime && p.ProcessID < q.ProcessID) {
+				next = i
+			}
+		}
+		return next
+	}})
+
+	res := s.result(title)
+	outputResult(w, opts, res)
+	return res
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestLRTFSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 1},
+	}
+	// P1 runs until the others catch up with it, then the three round down together, the ties
+	// going to the earliest arrival, and they all exit at the very end
+	want := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 3},
+		{PID: 2, Start: 3, Stop: 4},
+		{PID: 1, Start: 4, Stop: 5},
+		{PID: 2, Start: 5, Stop: 6},
+		{PID: 3, Start: 6, Stop: 7},
+	}
+	res := LRTFSchedule(context.Background(), io.Discard, "Longest-remaining-time-first", processes, SchedulerOptions{})
+	if !reflect.DeepEqual(res.Gantt, want) {
+		t.Errorf("Gantt = %v, want %v", res.Gantt, want)
+	}
+
+	// identical processes take turns every tick in PID order, whatever the row order; the
+	// order-independent schedulers' test can't cover this, as it wants them run one at a time
+	identical := []Process{
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2},
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2},
+	}
+	wantIdentical := []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 2}, {PID: 1, Start: 2, Stop: 3}, {PID: 2, Start: 3, Stop: 4}}
+	for _, order := range permutations(identical) {
+		got := LRTFSchedule(context.Background(), io.Discard, "Longest-remaining-time-first", order, SchedulerOptions{})
+		if !reflect.DeepEqual(got.Gantt, wantIdentical) {
+			t.Errorf("rows %v: Gantt = %v, want %v", order, got.Gantt, wantIdentical)
+		}
+	}
+
+	srtf := SRTFSchedule(context.Background(), io.Discard, "Shortest-remaining-time-first", processes, SchedulerOptions{})
+	if res.AvgWait <= srtf.AvgWait {
+		t.Errorf("AvgWait = %.2f, want it worse than SRTF's %.2f on the same processes", res.AvgWait, srtf.AvgWait)
+	}
+}
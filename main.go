@@ -1,516 +1,1725 @@
-package main
-
-import (
-	"encoding/csv"
-	"errors"
-	"fmt"
-	"io"
-	"log"
-	"os"
-	"strconv"
-	"strings"
-
-	"github.com/olekukonko/tablewriter"
-)
-
-func main() {
-	// CLI args
-	f, closeFile, err := openProcessingFile(os.Args...)
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer closeFile()
-
-	// Load and parse processes
-	processes, err := loadProcesses(f)
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	// First-come, first-serve scheduling
-	FCFSSchedule(os.Stdout, "First-come, first-serve", processes)
-
-	SJFSchedule(os.Stdout, "Shortest-job-first", processes)
-	//
-	SJFPrioritySchedule(os.Stdout, "Priority", processes)
-	//
-	RRSchedule(os.Stdout, "Round-robin", processes)
-}
-
-func openProcessingFile(args ...string) (*os.File, func(), error) {
-	if len(args) != 2 {
-		return nil, nil, fmt.Errorf("%w: must give a scheduling file to process", ErrInvalidArgs)
-	}
-	// Read in CSV process CSV file
-	f, err := os.Open(args[1])
-	if err != nil {
-		return nil, nil, fmt.Errorf("%v: error opening scheduling file", err)
-	}
-	closeFn := func() {
-		if err := f.Close(); err != nil {
-			log.Fatalf("%v: error closing scheduling file", err)
-		}
-	}
-
-	return f, closeFn, nil
-}
-
-type (
-	Process struct {
-		ProcessID     int64
-		ArrivalTime   int64
-		BurstDuration int64
-		Priority      int64
-	}
-	TimeSlice struct {
-		PID   int64
-		Start int64
-		Stop  int64
-	}
-
-	ProcessData struct {
-		TotalWait int64
-		TAround   int64
-		ExitTime  int64
-	}
-)
-
-//region Schedulers
-
-// FCFSSchedule outputs a schedule of processes in a GANTT chart and a table of timing given:
-// • an output writer
-// • a title for the chart
-// • a slice of processes
-func FCFSSchedule(w io.Writer, title string, processes []Process) {
-	var (
-		serviceTime     int64
-		totalWait       float64
-		totalTurnaround float64
-		lastCompletion  float64
-		waitingTime     int64
-		schedule        = make([][]string, len(processes))
-		gantt           = make([]TimeSlice, 0)
-	)
-	for i := range processes {
-		if processes[i].ArrivalTime > 0 {
-			waitingTime = serviceTime - processes[i].ArrivalTime
-		}
-		totalWait += float64(waitingTime)
-
-		start := waitingTime + processes[i].ArrivalTime
-
-		turnaround := processes[i].BurstDuration + waitingTime
-		totalTurnaround += float64(turnaround)
-
-		completion := processes[i].BurstDuration + processes[i].ArrivalTime + waitingTime
-		lastCompletion = float64(completion)
-
-		schedule[i] = []string{
-			fmt.Sprint(processes[i].ProcessID),
-			fmt.Sprint(processes[i].Priority),
-			fmt.Sprint(processes[i].BurstDuration),
-			fmt.Sprint(processes[i].ArrivalTime),
-			fmt.Sprint(waitingTime),
-			fmt.Sprint(turnaround),
-			fmt.Sprint(completion),
-		}
-		serviceTime += processes[i].BurstDuration
-
-		gantt = append(gantt, TimeSlice{
-			PID:   processes[i].ProcessID,
-			Start: start,
-			Stop:  serviceTime,
-		})
-	}
-
-	count := float64(len(processes))
-	aveWait := totalWait / count
-	aveTurnaround := totalTurnaround / count
-	aveThroughput := count / lastCompletion
-
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
-}
-
-func CheckIfDone(pd []ProcessData) bool { // if any of the process have not been finished
-	for _, x := range pd {
-		if x.ExitTime == 0 { // exit time zero means it never started
-			return false
-		}
-	}
-	return true
-}
-
-func SJFPrioritySchedule(w io.Writer, title string, processes []Process) {
-	var (
-		totalWait       float64
-		totalTurnaround float64
-		schedule        = make([][]string, len(processes))
-		gantt           = make([]TimeSlice, 0)
-	)
-
-	TempProcesses := make([]Process, len(processes)) // make new array to manipulate without affecting parent
-	copy(TempProcesses, processes)
-
-	pd := make([]ProcessData, len(TempProcesses)) // new array to keep track of process data
-	for i := range pd {
-		pd[i] = ProcessData{TotalWait: 0, TAround: 0, ExitTime: 0}
-	}
-
-	var time, start int64 = 0, 0 // used to keep track of the current time
-	current := 0                 // keep track of current process being handled
-
-	for !CheckIfDone(pd) { // while all processes are not finished
-		swapped := false
-		for index, proc := range pd { // at the start of the each cycle
-			if TempProcesses[index].ArrivalTime < time { // if process has arrived
-				if index != current && proc.ExitTime == 0 { // if it is not currently being worked
-					pd[index].TotalWait += 1 //increase wait time by one
-				} else if index == current { // if the process is currently being worked on
-					TempProcesses[index].BurstDuration--
-					if TempProcesses[index].BurstDuration == 0 {
-						swapped = true
-						pd[index].ExitTime = time
-					}
-				}
-			}
-		}
-		new := 0
-		for index, proc := range processes {
-			if pd[index].ExitTime == 0 && proc.ArrivalTime <= time { // if the process is not already finished, and it has arrived
-				// if the process at the index has a shorter burst time than the currently running one, or the current is finished, or there is a tie and the new process has a higher priortiy
-				if TempProcesses[index].BurstDuration < TempProcesses[current].BurstDuration || // if the process has a shorter burst duration than the current one
-					TempProcesses[current].BurstDuration < 1 || // if the current task is finished
-					(TempProcesses[index].BurstDuration <= TempProcesses[current].BurstDuration && TempProcesses[index].Priority > TempProcesses[current].Priority) { //if the process have equal duration left, use priority as a tie breaker
-					new = index
-					swapped = true
-				}
-			}
-		}
-		if swapped { // if the current process has lost priority or the last one is done
-			gantt = append(gantt, TimeSlice{ // place previous process in gantt table before switching processes
-				PID:   int64(current + 1),
-				Start: start,
-				Stop:  time,
-			})
-			current = new // set the the process to be currently working
-			start = time  // set the time
-		}
-
-		time++ // increment time
-	}
-
-	for i, proc := range pd {
-		schedule[i] = []string{
-			fmt.Sprint(processes[i].ProcessID),
-			fmt.Sprint(processes[i].Priority),
-			fmt.Sprint(processes[i].BurstDuration),
-			fmt.Sprint(processes[i].ArrivalTime),
-			fmt.Sprint(proc.TotalWait),
-			fmt.Sprint(proc.TotalWait + processes[i].BurstDuration),
-			fmt.Sprint(proc.ExitTime),
-		}
-
-		totalTurnaround += float64(proc.TotalWait) + float64(processes[i].BurstDuration) // get total turnaround time
-		totalWait += float64(proc.TotalWait)
-	}
-	count := float64(len(processes))
-	aveWait := totalWait / count
-	aveTurnaround := totalTurnaround / count
-	aveThroughput := count / float64(time-1) //final time will be one less than counted time
-
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
-
-}
-
-
-
-func SJFSchedule(w io.Writer, title string, processes []Process) {
-	var (
-		totalWait       float64
-		totalTurnaround float64
-		schedule        = make([][]string, len(processes))
-		gantt           = make([]TimeSlice, 0)
-	)
-
-	TempProcesses := make([]Process, len(processes)) // make new array to manipulate without affecting parent
-	copy(TempProcesses, processes)
-
-	pd := make([]ProcessData, len(TempProcesses)) // new array to keep track of process data
-	for i := range pd {
-		pd[i] = ProcessData{TotalWait: 0, TAround: 0, ExitTime: 0}
-	}
-
-	var time, start int64 = 0, 0 // used to keep track of the current time
-	current := 0                 // keep track of current process being handled
-
-	for !CheckIfDone(pd) { // while all processes are not finished
-		swapped := false
-		for index, proc := range pd { // at the start of the each cycle
-			if TempProcesses[index].ArrivalTime < time { // if process has arrived
-				if index != current && proc.ExitTime == 0 { // if it is not currently being worked
-					pd[index].TotalWait += 1 //increase wait time by one
-				} else if index == current { // if the process is currently being worked on
-					TempProcesses[index].BurstDuration--
-					if TempProcesses[index].BurstDuration == 0 {
-						
-						swapped = true
-						pd[index].ExitTime = time
-					}
-				}
-			}
-		}
-		new := 0
-		for index, proc := range processes {
-			// if(proc.ArrivalTime >= time && pd[index].ExitTime == 0 && new != index){ 
-			// 	if(pd[new].ExitTime != 0){
-			// 		new = index
-			// 		swapped = true
-			// 	} else if(TempProcesses[index].BurstDuration < TempProcesses[new].BurstDuration){
-			// 		new = index
-			// 	swapped = true
-			// 	}
-				
-			// }
-
-
-			if pd[index].ExitTime == 0 && proc.ArrivalTime <= time { // if the process is not already finished, and it has arrived
-				if TempProcesses[index].BurstDuration < TempProcesses[current].BurstDuration || TempProcesses[current].BurstDuration < 1 { // if the process at the index has a shorter burst time than the currently running one, or the current is finished
-					if(swapped || index == new){
-						if(TempProcesses[index].BurstDuration < TempProcesses[new].BurstDuration && TempProcesses[index].BurstDuration > 0){
-						
-
-							new = index
-						}
-					}else	{
-						
-						new = index
-					swapped = true
-					}
-					// 	fmt.Printf("%d new going to %d\n",new+1,index+1)
-					// 	new = index
-					// swapped = true
-					// }
-
-					new = index
-					swapped = true
-				}
-			}
-		}
-		if swapped { // if the current process has lost priority or the last one is done
-			gantt = append(gantt, TimeSlice{ // place previous process in gantt table before switching processes
-				PID:   int64(current + 1),
-				Start: start,
-				Stop:  time,
-			})
-			
-			current = new // set the the process to be currently working
-			start = time  // set the time
-		}
-
-		time++ // increment time
-	}
-
-	for i, proc := range pd {
-		schedule[i] = []string{
-			fmt.Sprint(processes[i].ProcessID),
-			fmt.Sprint(processes[i].Priority),
-			fmt.Sprint(processes[i].BurstDuration),
-			fmt.Sprint(processes[i].ArrivalTime),
-			fmt.Sprint(proc.TotalWait),
-			fmt.Sprint(proc.TotalWait + processes[i].BurstDuration),
-			fmt.Sprint(proc.ExitTime),
-		}
-
-		totalTurnaround += float64(proc.TotalWait) + float64(processes[i].BurstDuration) // get total turnaround time
-		totalWait += float64(proc.TotalWait)
-	}
-	count := float64(len(processes))
-	aveWait := totalWait / count
-	aveTurnaround := totalTurnaround / count
-	aveThroughput := count / float64(time-1) //final time will be one less than counted time
-
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
-
-}
-
-func getNextProcess(pd []ProcessData, proc []Process, current int, time int64) int {
-	counter, max := 0, len(proc) // intiate variables
-	current++
-	if current >= max {
-		current = 0
-	}
-
-	for counter < max {
-		if pd[current].ExitTime == 0 && proc[current].ArrivalTime <= time { // if the process isn't done and has arrived
-			return current
-		} else {
-			if current < (max - 1) { // increment to next one
-				current++
-			} else {
-				current = 0
-			}
-			counter++
-		}
-	}
-	return -1 // if all jobs are done
-
-}
-
-func RRSchedule(w io.Writer, title string, processes []Process) {
-
-	var (
-		totalWait       float64
-		totalTurnaround float64
-		schedule        = make([][]string, len(processes))
-		gantt           = make([]TimeSlice, 0)
-	)
-	quantum := 0
-
-	TempProcesses := make([]Process, len(processes)) // make new array to manipulate without affecting parent
-	copy(TempProcesses, processes)
-
-	pd := make([]ProcessData, len(TempProcesses)) // new array to keep track of process data
-	for i := range pd {
-		pd[i] = ProcessData{TotalWait: 0, TAround: 0, ExitTime: 0}
-	}
-
-	var time, start int64 = 0, 0                      // used to keep track of the current time
-	current := getNextProcess(pd, processes, 0, time) // keep track of current process being handled
-	for current > -1 {
-		for index, proc := range pd { // at the start of the each cycle
-			if TempProcesses[index].ArrivalTime < time { // if process has arrived
-				if index != current && proc.ExitTime == 0 { // if it is not currently being worked
-					pd[index].TotalWait += 1 //increase wait time by one
-				} else if index == current { // if the process is currently being worked on
-					TempProcesses[index].BurstDuration--
-					if TempProcesses[index].BurstDuration == 0 {
-						pd[index].ExitTime = time
-					}
-				}
-			}
-		}
-
-		if quantum < 2 && pd[current].ExitTime == 0 { // if under the time quantum(2) and has not finished
-			quantum++
-		} else {
-			quantum = 1
-			next := getNextProcess(pd, processes, current, time) // get the next index in the round robin
-			if next != current {                                 // if the new pid is not the same as the current update gantt
-				gantt = append(gantt, TimeSlice{
-					PID:   processes[current].ProcessID,
-					Start: start,
-					Stop:  time,
-				})
-				start = time
-				current = next
-			}
-		}
-		time++
-	}
-
-	for i, proc := range pd {
-		schedule[i] = []string{
-			fmt.Sprint(processes[i].ProcessID),
-			fmt.Sprint(processes[i].Priority),
-			fmt.Sprint(processes[i].BurstDuration),
-			fmt.Sprint(processes[i].ArrivalTime),
-			fmt.Sprint(proc.TotalWait),
-			fmt.Sprint(proc.TotalWait + processes[i].BurstDuration),
-			fmt.Sprint(proc.ExitTime),
-		}
-
-		totalTurnaround += float64(proc.TotalWait) + float64(processes[i].BurstDuration) // get total turnaround time
-		totalWait += float64(proc.TotalWait)
-	}
-	count := float64(len(processes))
-	aveWait := totalWait / count
-	aveTurnaround := totalTurnaround / count
-	aveThroughput := count / float64(time-1) //final time will be one less than counted time
-
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
-}
-
-//endregion
-
-//region Output helpers
-
-func outputTitle(w io.Writer, title string) {
-	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
-	_, _ = fmt.Fprintln(w, strings.Repeat(" ", len(title)/2), title)
-	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
-}
-
-func outputGantt(w io.Writer, gantt []TimeSlice) {
-	_, _ = fmt.Fprintln(w, "Gantt schedule")
-	_, _ = fmt.Fprint(w, "|")
-	for i := range gantt {
-		pid := fmt.Sprint(gantt[i].PID)
-		padding := strings.Repeat(" ", (8-len(pid))/2)
-		_, _ = fmt.Fprint(w, padding, pid, padding, "|")
-	}
-	_, _ = fmt.Fprintln(w)
-	for i := range gantt {
-		_, _ = fmt.Fprint(w, fmt.Sprint(gantt[i].Start), "\t")
-		if len(gantt)-1 == i {
-			_, _ = fmt.Fprint(w, fmt.Sprint(gantt[i].Stop))
-		}
-	}
-	_, _ = fmt.Fprintf(w, "\n\n")
-}
-
-func outputSchedule(w io.Writer, rows [][]string, wait, turnaround, throughput float64) {
-	_, _ = fmt.Fprintln(w, "Schedule table")
-	table := tablewriter.NewWriter(w)
-	table.SetHeader([]string{"ID", "Priority", "Burst", "Arrival", "Wait", "Turnaround", "Exit"})
-	table.AppendBulk(rows)
-	table.SetFooter([]string{"", "", "", "",
-		fmt.Sprintf("Average\n%.2f", wait),
-		fmt.Sprintf("Average\n%.2f", turnaround),
-		fmt.Sprintf("Throughput\n%.2f/t", throughput)})
-	table.Render()
-}
-
-//endregion
-
-//region Loading processes.
-
-var ErrInvalidArgs = errors.New("invalid args")
-
-func loadProcesses(r io.Reader) ([]Process, error) {
-	rows, err := csv.NewReader(r).ReadAll()
-	if err != nil {
-		return nil, fmt.Errorf("%w: reading CSV", err)
-	}
-
-	processes := make([]Process, len(rows))
-	for i := range rows {
-		processes[i].ProcessID = mustStrToInt(rows[i][0])
-		processes[i].BurstDuration = mustStrToInt(rows[i][1])
-		processes[i].ArrivalTime = mustStrToInt(rows[i][2])
-		if len(rows[i]) == 4 {
-			processes[i].Priority = mustStrToInt(rows[i][3])
-		}
-	}
-
-	return processes, nil
-}
-
-func mustStrToInt(s string) int64 {
-	i, err := strconv.ParseInt(s, 10, 64)
-	if err != nil {
-		_, _ = fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
-	}
-
-	return i
-}
-
-//endregion
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+func main() {
+	slog.SetDefault(newLogger(os.Stderr, &logLevel))
+	cmd, args := splitCommand(os.Args[1:])
+	if err := cmd.run(args); err != nil {
+		slog.Error(err.Error())
+		os.Exit(1)
+	}
+}
+
+// openProcessingFile opens the scheduling file named by args[1], or returns os.Stdin when args
+// names no file, so processes can be piped in. The returned function closes the file; it does
+// nothing for stdin.
+func openProcessingFile(args ...string) (*os.File, func(), error) {
+	switch {
+	case len(args) == 1:
+		return os.Stdin, func() {}, nil
+	case len(args) != 2:
+		return nil, nil, fmt.Errorf("%w: must give at most one scheduling file to process", ErrInvalidArgs)
+	}
+	// Read in CSV process CSV file
+	f, err := os.Open(args[1])
+	if err != nil {
+		return nil, nil, fmt.Errorf("%v: error opening scheduling file", err)
+	}
+	closeFn := func() {
+		if err := f.Close(); err != nil {
+			slog.Error("error closing scheduling file", "err", err)
+			os.Exit(1)
+		}
+	}
+
+	return f, closeFn, nil
+}
+
+type (
+	Process struct {
+		ProcessID     int64
+		ArrivalTime   int64
+		BurstDuration int64
+		// Priority may be fractional for fine-grained ordering; integer priorities still load
+		// and print as before.
+		Priority float64
+		// LockAt and LockFor describe when the process holds the shared resource: it needs the
+		// resource after running for LockAt ticks and keeps it for LockFor ticks of execution.
+		// A LockFor of zero means the process never uses the resource.
+		LockAt  int64
+		LockFor int64
+		// ReleaseJitter delays when the process becomes schedulable after it arrives.
+		ReleaseJitter int64
+		// Weight is the process's share of the CPU under weighted schedulers, independent of
+		// Priority; the loader defaults it to 1 and zero also counts as 1.
+		Weight int64
+		// Deadline is the absolute time the process should have exited by; zero means none.
+		Deadline int64
+		// NonPreemptible processes run to completion once dispatched under the preemptive
+		// priority and shortest-job-first schedulers.
+		NonPreemptible bool
+		// Bursts, when set, alternates the process's CPU bursts with I/O bursts during which it
+		// is blocked; its CPU bursts add up to BurstDuration. Every scheduler simulates the I/O
+		// except GanttFromOrder, which runs the CPU bursts back to back and says so in its notes.
+		Bursts []Burst
+	}
+	TimeSlice struct {
+		PID   int64 `json:"pid"` // IdlePID for idle time in the exported charts
+		Start int64 `json:"start"`
+		Stop  int64 `json:"stop"`
+	}
+
+	// BurndownTick is every process's remaining burst at the start of one tick (see
+	// SchedulerOptions.Burndown).
+	BurndownTick struct {
+		Time int64
+		// Remaining is the burst each process had left, in input order, work lost to preemption
+		// included.
+		Remaining []int64
+	}
+
+	ProcessData struct {
+		// TotalWait counts the ticks the process had arrived and not exited but wasn't working:
+		// ready but not chosen, held back by release jitter, or sitting through round-robin
+		// dispatcher overhead. Time blocked on I/O is not waiting. The schedulers never idle while
+		// a released process waits, so it is always the turnaround minus the burst, any lost work
+		// and any I/O, idle gaps included.
+		TotalWait int64
+		TAround   int64
+		ExitTime  int64
+		LostWork  int64
+		// FirstRun is when the process first got the CPU, or -1 if it never ran.
+		FirstRun int64
+		// Remaining is the burst left to run when the simulation stopped before the process exited.
+		Remaining int64
+		// Laxity is the process's laxity (see laxity) at its latest dispatch, which is its least
+		// at any dispatch as laxity only shrinks. It is zero for a process without a deadline.
+		Laxity int64
+		// EffectivePriority is the priority the process competes with under aging (see
+		// SchedulerOptions.Aging): its own priority, improved by one step for every Aging ticks it
+		// has waited since it last ran.
+		EffectivePriority float64
+		// IO are the spans the process spent blocked on I/O, in order (see Process.Bursts).
+		IO []TimeSlice
+		// State is what the process was doing when the simulation stopped.
+		State ProcessState
+	}
+
+	// SchedulerOptions tunes how the preemptive schedulers simulate a workload.
+	SchedulerOptions struct {
+		// PreemptPenalty is the fraction of the work done since dispatch that a
+		// preempted process loses and must redo (a cache-cold restart).
+		PreemptPenalty float64
+		// Events prints a chronological event log after the schedule table.
+		Events bool
+		// Describe prints a one-line description of each algorithm's policy before its schedule.
+		Describe bool
+		// Quantum is the round-robin time slice of at least 1 tick; zero means defaultQuantum, and
+		// RRSchedule rejects a negative one.
+		Quantum int64
+		// MLFQQuanta are the quanta of the multilevel feedback queues, from the top queue down;
+		// empty means defaultMLFQQuanta.
+		MLFQQuanta []int64
+		// RROverhead is the fraction [0, 1) of every round-robin quantum the dispatcher consumes:
+		// the clock still advances by the full quantum, but only the rest does useful work.
+		RROverhead float64
+		// GanttScale, when positive, draws the Gantt chart proportionally with this many
+		// characters per time unit instead of fixed-width cells.
+		GanttScale int
+		// Format selects how results are rendered, one of outputFormats; empty means "table". "plain"
+		// is the table without tablewriter's borders. The schedulers render nothing for "json", "csv"
+		// and "svg", which writeResults renders for all the results at once.
+		Format string
+		// ShowWeight adds the Weight column to the schedule table; runAlgorithms sets it when
+		// any weighted scheduler runs.
+		ShowWeight bool
+		// Columns, when set, are the schedule table columns to show and their order, from
+		// tableColumnNames; naming an optional column such as Deadline shows it regardless.
+		Columns []string
+		// ExcludeNeverRun leaves the processes that never ran out of the schedule table and
+		// lists them on one line instead. Averages only ever cover the processes that exited.
+		ExcludeNeverRun bool
+		// PriorityOrder is which end of the priority scale the priority-aware schedulers run
+		// first; each scheduler documents its own default.
+		PriorityOrder PriorityOrder
+		// Aging, when positive, improves a waiting process's priority by one step every Aging
+		// ticks it waits under the preemptive priority scheduler, resetting it once it runs.
+		Aging int64
+		// Horizon, when positive, stops the simulation at this simulated time; processes still
+		// unfinished are reported with their remaining burst and left out of the averages.
+		Horizon int64
+		// MaxTicks, when positive, fails a tick-based simulation still running after this many
+		// ticks with ErrTickLimit, a safety net against a schedule that never terminates.
+		MaxTicks int64
+		// Backlog is how many processes applyBacklog marked as already waiting at time 0; it is
+		// only reported, the processes themselves carry the change.
+		Backlog int
+		// Explain prints the arithmetic behind the averages and throughput under the table.
+		Explain bool
+		// Cumulative reports the running average wait and turnaround after every completion.
+		Cumulative bool
+		// GroupBy, when its Column is set, reports the average wait and turnaround of each
+		// cohort of processes under the table.
+		GroupBy Grouping
+		// Trace, when set, receives a line per tick from the tick-based schedulers, every one but
+		// first-come, first-serve (see traceTick), for following their decisions apart from the
+		// rendered output.
+		Trace io.Writer
+		// Burndown records every process's remaining burst at each tick in ScheduleResult.Burndown,
+		// for the -burndown curves.
+		Burndown bool
+		// Clock creates the clock each tick-based simulation advances; nil means a TickClock.
+		Clock func() Clock
+		// aggregateOnly skips building the table rows and rendering, for Metrics.
+		aggregateOnly bool
+		// sliceHook is passed every Gantt slice as soon as the simulation completes it, for
+		// ScheduleStream.
+		sliceHook func(TimeSlice)
+	}
+
+	// ScheduleResult holds everything a scheduler computed, ready for rendering.
+	ScheduleResult struct {
+		Title         string
+		Header        []string
+		Rows          [][]string
+		Gantt         []TimeSlice
+		Data          []ProcessData
+		AvgWait       float64
+		AvgTurnaround float64
+		// AvgNormTurnaround averages the completed processes' turnaround over burst (see
+		// avgNormTurnaround), how many times its own burst a process spent in the system.
+		AvgNormTurnaround float64
+		Throughput        float64
+		// Utilization is the fraction of the simulated time up to StoppedAt the CPU spent running
+		// processes, not counting idle gaps or round-robin dispatcher overhead.
+		Utilization float64
+		// ContextSwitches counts the changes of running process along the Gantt chart; idle time
+		// between two slices of the same process is not a switch.
+		ContextSwitches int
+		// FairnessIndex is Jain's index of the completed processes' turnarounds (see
+		// turnaroundFairness): 1 when they all took as long, down to 1/n when one took everything.
+		FairnessIndex float64
+		// AvgResponse averages how long processes waited from arrival until first running;
+		// WeightedResponse weighs each process's response by its burst, emphasising large jobs.
+		AvgResponse      float64
+		WeightedResponse float64
+		LostWork         int64
+		// IdleTicks counts the ticks the CPU had no released process to run, for the schedulers
+		// that track it (round-robin).
+		IdleTicks int64
+		// OverheadTicks counts the ticks the round-robin dispatcher held the CPU under
+		// SchedulerOptions.RROverhead.
+		OverheadTicks int64
+		// Deadlines checks every process with a deadline against its exit, for the schedulers
+		// that analyse deadlines (round-robin).
+		Deadlines []DeadlineCheck
+		// MissedDeadlines counts the processes with a deadline that exited after it or not at all.
+		MissedDeadlines int
+		// Cumulative holds the running averages after each completion when
+		// SchedulerOptions.Cumulative is set.
+		Cumulative []CumulativeMetrics
+		// Little cross-checks the averages of a complete schedule with Little's law.
+		Little LittlesLaw
+		// MakespanGap compares a complete preemptive schedule's length with its lower bound;
+		// it is zero for the other schedules.
+		MakespanGap MakespanGap
+		// Burndown has a tick from the start of the simulation to StoppedAt when
+		// SchedulerOptions.Burndown is set; it is nil from schedulers that don't record it.
+		Burndown []BurndownTick
+		// Notes are scheduler-specific remarks printed after the schedule table.
+		Notes []string
+		// Err is the context's error when the simulation was cancelled before every
+		// process exited, ErrHorizon when it reached SchedulerOptions.Horizon, or wraps
+		// ErrTickLimit when it ran past SchedulerOptions.MaxTicks; the
+		// result then only covers the simulation up to StoppedAt. GanttFromOrder also
+		// reports an invalid order here, ScheduleStream an unknown algorithm and RRSchedule a
+		// negative quantum, as ErrInvalidArgs errors.
+		Err       error
+		StoppedAt int64
+	}
+)
+
+//region Schedulers
+
+// Scheduler simulates a scheduling policy: it schedules the processes until they all exit or
+// ctx is done, renders the result to w under the title as opts asks, and returns it.
+type Scheduler interface {
+	Schedule(ctx context.Context, w io.Writer, title string, processes []Process, opts SchedulerOptions) ScheduleResult
+}
+
+// SchedulerFunc adapts a scheduling function such as FCFSSchedule to the Scheduler interface.
+type SchedulerFunc func(ctx context.Context, w io.Writer, title string, processes []Process, opts SchedulerOptions) ScheduleResult
+
+// Schedule calls f(ctx, w, title, processes, opts).
+func (f SchedulerFunc) Schedule(ctx context.Context, w io.Writer, title string, processes []Process, opts SchedulerOptions) ScheduleResult {
+	return f(ctx, w, title, processes, opts)
+}
+
+// FCFSSchedule outputs a schedule of processes in a GANTT chart and a table of timing given:
+// • a context that stops the schedule early when cancelled
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+// • the scheduler options
+//
+// The processes run in order of arrival, ties broken by PID, whatever their order in the slice,
+// which is left as is; the result's Data and Rows still follow the slice like the other schedulers'.
+// Processes with I/O bursts are simulated tick by tick instead (see fcfsIOSchedule).
+func FCFSSchedule(ctx context.Context, w io.Writer, title string, processes []Process, opts SchedulerOptions) ScheduleResult {
+	if hasIOBursts(processes) {
+		res := fcfsIOSchedule(ctx, title, processes, opts)
+		outputResult(w, opts, res)
+		return res
+	}
+	order := make([]int, len(processes)) // indexes into processes in the order they run
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool {
+		pa, pb := processes[order[a]], processes[order[b]]
+		return pa.ArrivalTime < pb.ArrivalTime || (pa.ArrivalTime == pb.ArrivalTime && pa.ProcessID < pb.ProcessID)
+	})
+	sorted := make([]Process, len(processes))
+	for i, j := range order {
+		sorted[i] = processes[j]
+	}
+	res := fcfsResult(ctx, title, sorted, opts)
+
+	// a cancelled run only has data for the processes it got to, so put those back in input order
+	ran := order[:len(res.Data)]
+	byInput := make([]int, len(ran)) // indexes into res.Data in input order
+	for k := range byInput {
+		byInput[k] = k
+	}
+	sort.Slice(byInput, func(a, b int) bool { return ran[byInput[a]] < ran[byInput[b]] })
+	data := make([]ProcessData, len(res.Data))
+	for k, j := range byInput {
+		data[k] = res.Data[j]
+	}
+	if res.Rows != nil {
+		rows := make([][]string, len(res.Rows))
+		for k, j := range byInput {
+			rows[k] = res.Rows[j]
+		}
+		res.Rows = rows
+	}
+	res.Data = data
+
+	outputResult(w, opts, res)
+	return res
+}
+
+// fcfsIOSchedule is FCFSSchedule for processes that block on I/O: a free CPU goes to the ready
+// process that joined the queue first, on arrival or back from its I/O, ties broken by arrival then
+// PID, and keeps it until it exits or starts an I/O burst.
+func fcfsIOSchedule(ctx context.Context, title string, processes []Process, opts SchedulerOptions) ScheduleResult {
+	queued := func(s *tickSim, i int) int64 { // when process i last joined the ready queue
+		if spans := s.pd[i].IO; len(spans) > 0 {
+			return spans[len(spans)-1].Stop
+		}
+		return processes[i].ArrivalTime
+	}
+	s := runTicks(ctx, title, processes, opts, tickPolicy{next: func(s *tickSim) int {
+		if s.current >= 0 {
+			return s.current // only a free CPU picks the next process
+		}
+		next := -1
+		for i, p := range processes {
+			if !s.ready(i) {
+				continue
+			}
+			if next < 0 || queued(s, i) < queued(s, next) ||
+				(queued(s, i) == queued(s, next) && p.ArrivalTime < processes[next].ArrivalTime) ||
+				(queued(s, i) == queued(s, next) && p.ArrivalTime == processes[next].ArrivalTime && p.ProcessID < processes[next].ProcessID) {
+				next = i
+			}
+		}
+		return next
+	}})
+	return s.result(title)
+}
+
+// fcfsResult runs each process to completion in the order given, which GanttFromOrder relies on.
+func fcfsResult(ctx context.Context, title string, processes []Process, opts SchedulerOptions) ScheduleResult {
+	var (
+		serviceTime     int64
+		totalWait       float64
+		totalTurnaround float64
+		lastCompletion  float64
+		waitingTime     int64
+		cancelErr       error
+		schedule        [][]string
+		cols            = scheduleColumns(processes, opts)
+		gantt           = make([]TimeSlice, 0)
+		pd              = make([]ProcessData, 0, len(processes))
+	)
+	if !opts.aggregateOnly {
+		schedule = make([][]string, 0, len(processes))
+	}
+	for i := range processes {
+		if cancelErr = ctx.Err(); cancelErr != nil {
+			break
+		}
+		if release := releaseTime(processes[i]); serviceTime < release {
+			serviceTime = release // the CPU idles until the process is released
+		}
+		waitingTime = serviceTime - processes[i].ArrivalTime
+		completion := processes[i].BurstDuration + processes[i].ArrivalTime + waitingTime
+		if opts.Horizon > 0 && completion > opts.Horizon {
+			cancelErr = ErrHorizon
+			break
+		}
+		totalWait += float64(waitingTime)
+
+		start := waitingTime + processes[i].ArrivalTime
+
+		turnaround := processes[i].BurstDuration + waitingTime
+		totalTurnaround += float64(turnaround)
+
+		lastCompletion = float64(completion)
+
+		proc := ProcessData{TotalWait: waitingTime, TAround: turnaround, ExitTime: completion, FirstRun: start,
+			Laxity: laxity(processes[i], start, processes[i].BurstDuration)}
+		if !opts.aggregateOnly {
+			schedule = append(schedule, scheduleRow(processes[i], proc, cols, turnaround))
+		}
+		serviceTime += processes[i].BurstDuration
+		pd = append(pd, proc)
+
+		gantt = append(gantt, TimeSlice{
+			PID:   processes[i].ProcessID,
+			Start: start,
+			Stop:  serviceTime,
+		})
+		opts.emitSlice(gantt[len(gantt)-1])
+	}
+
+	completed := len(pd)
+	if errors.Is(cancelErr, ErrHorizon) { // report the processes the horizon cut off as unfinished
+		lastCompletion = float64(opts.Horizon)
+		for _, p := range processes[completed:] {
+			start := serviceTime
+			if release := releaseTime(p); start < release {
+				start = release
+			}
+			proc := ProcessData{FirstRun: -1, Remaining: p.BurstDuration}
+			if start < opts.Horizon {
+				proc.FirstRun = start
+				proc.Laxity = laxity(p, start, p.BurstDuration)
+				proc.Remaining -= opts.Horizon - start
+				gantt = append(gantt, TimeSlice{PID: p.ProcessID, Start: start, Stop: opts.Horizon})
+				opts.emitSlice(gantt[len(gantt)-1])
+			} else {
+				start = opts.Horizon // waited until the horizon
+			}
+			if start > p.ArrivalTime {
+				proc.TotalWait = start - p.ArrivalTime
+			}
+			serviceTime = start + p.BurstDuration
+			pd = append(pd, proc)
+			if !opts.aggregateOnly {
+				schedule = append(schedule, scheduleRow(p, proc, cols, 0))
+			}
+		}
+	}
+
+	res := ScheduleResult{
+		Title:     title,
+		Header:    scheduleHeader(cols),
+		Rows:      schedule,
+		Gantt:     gantt,
+		Data:      pd,
+		Err:       cancelErr,
+		StoppedAt: int64(lastCompletion),
+	}
+	for t := int64(0); opts.Burndown && t <= res.StoppedAt; t++ {
+		// the ith slice runs the ith process to completion, or as far as the horizon
+		res.Burndown = opts.burndownTick(res.Burndown, t, len(processes), func(i int) int64 {
+			if i >= len(gantt) || t <= gantt[i].Start {
+				return processes[i].BurstDuration
+			}
+			if t >= gantt[i].Stop {
+				return processes[i].BurstDuration - (gantt[i].Stop - gantt[i].Start)
+			}
+			return processes[i].BurstDuration - (t - gantt[i].Start)
+		})
+	}
+	if count := float64(completed); count > 0 {
+		res.AvgWait = totalWait / count
+		res.AvgTurnaround = totalTurnaround / count
+		res.Throughput = count / lastCompletion
+		if cancelErr == nil {
+			res.Throughput = computeThroughput(pd, completed)
+		}
+	}
+	res.Utilization = utilization(gantt, 0, res.StoppedAt)
+	res.ContextSwitches = contextSwitches(gantt)
+	res.FairnessIndex = turnaroundFairness(pd)
+	res.AvgNormTurnaround = avgNormTurnaround(processes, pd)
+	res.AvgResponse, res.WeightedResponse = responseTimes(processes, pd)
+	if opts.Cumulative {
+		res.Cumulative = cumulativeMetrics(processes, pd)
+	}
+	res.MissedDeadlines = missedDeadlines(processes, pd)
+	res.Little = littlesLaw(processes, res)
+	for i := range pd {
+		pd[i].State = finalState(processes[i], pd[i], gantt, res.StoppedAt)
+	}
+	res.Notes = unfinishedNotes(processes, pd, opts.ExcludeNeverRun)
+	if hasIOBursts(processes) {
+		res.Notes = append(res.Notes, ioIgnoredNote)
+	}
+	return res
+}
+
+// arrived reports whether a process has arrived by time t, the start of a tick. Every scheduler
+// uses the same convention: a process is available from its arrival time on, so a process
+// arriving at t waits during the tick [t, t+1) if it doesn't run in it, and can be selected to run
+// it once released at or before t.
+func arrived(p Process, t int64) bool {
+	return p.ArrivalTime <= t
+}
+
+// releaseTime is when a process becomes schedulable: its arrival delayed by any release jitter.
+// Waiting time still accrues from arrival, so jitter shows up as extra wait.
+func releaseTime(p Process) int64 {
+	return p.ArrivalTime + p.ReleaseJitter
+}
+
+// applyBacklog marks the first n processes as having arrived and been released at time 0,
+// whatever their arrival, so the schedulers start from a standing queue instead of a cold start.
+func applyBacklog(processes []Process, n int) error {
+	if n < 0 || n > len(processes) {
+		return fmt.Errorf("%w: backlog must be between 0 and %d processes, got %d", ErrInvalidArgs, len(processes), n)
+	}
+	for i := range processes[:n] {
+		processes[i].ArrivalTime, processes[i].ReleaseJitter = 0, 0
+	}
+	return nil
+}
+
+func CheckIfDone(pd []ProcessData) bool { // if any of the process have not been finished
+	for _, x := range pd {
+		if x.ExitTime == 0 { // exit time zero means it never started
+			return false
+		}
+	}
+	return true
+}
+
+// preemptionPenalty returns the whole ticks of work lost when a process is preempted
+// after running for work ticks since it was dispatched.
+func preemptionPenalty(work int64, fraction float64) int64 {
+	return int64(math.Round(fraction * float64(work)))
+}
+
+// SJFPrioritySchedule outputs a preemptive shortest-job-first schedule that breaks ties on priority,
+// the highest number winning unless opts.PriorityOrder says otherwise. A released process preempts
+// the running one when it has strictly less left, or as much left and a strictly better priority.
+// A free CPU goes to the released process with the least left, ties by priority, arrival then PID.
+func SJFPrioritySchedule(ctx context.Context, w io.Writer, title string, processes []Process, opts SchedulerOptions) ScheduleResult {
+	s := runTicks(ctx, title, processes, opts, tickPolicy{next: func(s *tickSim) int {
+		current, next := s.current, s.current
+		for i, p := range processes {
+			if !s.ready(i) {
+				continue
+			}
+			if next < 0 || s.remaining[i] < s.remaining[next] {
+				next = i
+				continue
+			}
+			if s.remaining[i] > s.remaining[next] {
+				continue
+			}
+			q := processes[next]
+			if opts.PriorityOrder.beats(p.Priority, q.Priority, HigherFirst) {
+				next = i
+			} else if next != current && !opts.PriorityOrder.beats(q.Priority, p.Priority, HigherFirst) &&
+				(p.ArrivalTime < q.ArrivalTime || (p.ArrivalTime == q.ArrivalTime && p.ProcessID < q.ProcessID)) {
+				next = i
+			}
+		}
+		return next
+	}})
+
+	res := s.result(title)
+	outputResult(w, opts, res)
+	return res
+}
+
+// SJFSchedule is the former name of SRTFSchedule, which it calls; see NonPreemptiveSJFSchedule
+// for the textbook non-preemptive shortest-job-first.
+//
+// Deprecated: use SRTFSchedule.
+func SJFSchedule(ctx context.Context, w io.Writer, title string, processes []Process, opts SchedulerOptions) ScheduleResult {
+	return SRTFSchedule(ctx, w, title, processes, opts)
+}
+
+// SRTFSchedule outputs a shortest-remaining-time-first schedule, the preemptive form of
+// shortest-job-first: a released process with a shorter remaining burst than the running one
+// preempts it. A free CPU goes to the released process with the least left, ties broken by
+// arrival then PID.
+func SRTFSchedule(ctx context.Context, w io.Writer, title string, processes []Process, opts SchedulerOptions) ScheduleResult {
+	protected := false // the current non-preemptible process already kept the CPU this dispatch
+	s := runTicks(ctx, title, processes, opts, tickPolicy{next: func(s *tickSim) int {
+		// the running process keeps the CPU unless a released process has strictly less left;
+		// otherwise the released, unfinished process with the least left runs, ties by arrival then PID
+		current, next := s.current, s.current
+		for i, p := range processes {
+			if !s.ready(i) {
+				continue
+			}
+			if next < 0 || s.remaining[i] < s.remaining[next] ||
+				(s.remaining[i] == s.remaining[next] && next != current &&
+					(p.ArrivalTime < processes[next].ArrivalTime || (p.ArrivalTime == processes[next].ArrivalTime && p.ProcessID < processes[next].ProcessID))) {
+				next = i
+			}
+		}
+		if current >= 0 && next != current && processes[current].NonPreemptible {
+			if !protected { // report each protected dispatch once
+				s.notes = append(s.notes, nonPreemptibleNote(s.time, processes[current], processes[next]))
+				protected = true
+			}
+			return current
+		}
+		if next != current {
+			protected = false
+		}
+		return next
+	}})
+
+	res := s.result(title)
+	outputResult(w, opts, res)
+	return res
+}
+
+// Completed returns how many processes exited within the result.
+func (r ScheduleResult) Completed() int {
+	var n int
+	for _, proc := range r.Data {
+		if proc.ExitTime != 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// defaultQuantum is the round-robin time slice used when none is configured.
+const defaultQuantum = 2
+
+// emitSlice passes a completed Gantt slice to the slice hook, if any.
+func (o SchedulerOptions) emitSlice(slice TimeSlice) {
+	if o.sliceHook != nil {
+		o.sliceHook(slice)
+	}
+}
+
+func (o SchedulerOptions) quantum() int {
+	if o.Quantum == 0 {
+		return defaultQuantum
+	}
+	return int(o.Quantum)
+}
+
+// contextSwitches counts the changes of running process along a Gantt chart.
+func contextSwitches(gantt []TimeSlice) int {
+	var (
+		n    int
+		last *TimeSlice
+	)
+	for i := range gantt {
+		if gantt[i].Start == gantt[i].Stop {
+			continue // nothing ran
+		}
+		if last != nil && last.PID != gantt[i].PID {
+			n++
+		}
+		last = &gantt[i]
+	}
+	return n
+}
+
+// responseTimes returns the average response time (first run minus arrival) of the processes
+// that ran, and the same average weighted by burst: Σ burst·response / Σ burst.
+func responseTimes(processes []Process, pd []ProcessData) (avg, weighted float64) {
+	var total, weightedTotal, bursts, ran float64
+	for i, proc := range pd {
+		if proc.FirstRun < 0 {
+			continue
+		}
+		response := float64(proc.FirstRun - processes[i].ArrivalTime)
+		total += response
+		weightedTotal += float64(processes[i].BurstDuration) * response
+		bursts += float64(processes[i].BurstDuration)
+		ran++
+	}
+	if ran == 0 {
+		return 0, 0
+	}
+	return total / ran, weightedTotal / bursts
+}
+
+// cancelCheckInterval is how many ticks a simulation runs between checks of its context.
+const cancelCheckInterval = 32
+
+// checkCancelled returns the context's error every cancelCheckInterval ticks once it is cancelled,
+// so long tick-based simulations can stop early without paying for a check on every tick.
+func checkCancelled(ctx context.Context, time int64) error {
+	if time%cancelCheckInterval != 0 {
+		return nil
+	}
+	return ctx.Err()
+}
+
+// tickResult builds the result of a tick-based scheduler whose clock stopped at elapsed, leaving
+// out the table rows when opts only asks for aggregates. Averages and throughput only cover the
+// processes that exited. A complete schedule also
+// measures its makespan gap, since the tick-based schedulers are the preemptive ones.
+func tickResult(title string, processes []Process, pd []ProcessData, gantt []TimeSlice, elapsed int64, opts SchedulerOptions, cancelErr error) ScheduleResult {
+	var (
+		totalWait       float64
+		totalTurnaround float64
+		completed       float64
+		cols            = scheduleColumns(processes, opts)
+		schedule        [][]string
+	)
+	if !opts.aggregateOnly {
+		schedule = make([][]string, len(processes))
+	}
+	for i, proc := range pd {
+		var turnaround int64 // left unset for a process that never exited
+		if proc.ExitTime != 0 {
+			turnaround = proc.TotalWait + processes[i].BurstDuration + proc.LostWork + proc.ioTicks()
+		}
+		pd[i].TAround = turnaround
+		pd[i].State = finalState(processes[i], proc, gantt, elapsed)
+		if schedule != nil {
+			schedule[i] = scheduleRow(processes[i], proc, cols, turnaround)
+		}
+		if proc.ExitTime == 0 {
+			continue
+		}
+		totalTurnaround += float64(turnaround) // get total turnaround time
+		totalWait += float64(proc.TotalWait)
+		completed++
+	}
+
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	res := ScheduleResult{
+		Title:     title,
+		Header:    scheduleHeader(cols),
+		Rows:      schedule,
+		Gantt:     gantt,
+		Data:      pd,
+		Err:       cancelErr,
+		StoppedAt: elapsed,
+	}
+	if completed > 0 {
+		res.AvgWait = totalWait / completed
+		res.AvgTurnaround = totalTurnaround / completed
+		res.Throughput = completed / float64(elapsed)
+		if cancelErr == nil {
+			res.Throughput = computeThroughput(pd, int(completed))
+		}
+	}
+	res.Utilization = utilization(gantt, 0, elapsed)
+	res.ContextSwitches = contextSwitches(gantt)
+	res.FairnessIndex = turnaroundFairness(pd)
+	res.AvgNormTurnaround = avgNormTurnaround(processes, pd)
+	res.AvgResponse, res.WeightedResponse = responseTimes(processes, pd)
+	res.MissedDeadlines = missedDeadlines(processes, pd)
+	if cancelErr == nil {
+		res.MakespanGap = makespanGap(processes, pd, gantt)
+	}
+	if opts.Cumulative {
+		res.Cumulative = cumulativeMetrics(processes, pd)
+	}
+	res.Little = littlesLaw(processes, res)
+	res.Notes = unfinishedNotes(processes, pd, opts.ExcludeNeverRun)
+	return res
+}
+
+// computeThroughput is the throughput of a complete schedule: the count of processes that exited
+// per time unit until the last exit. A schedule that stopped early divides by StoppedAt instead,
+// as its last exit isn't the end of the work.
+func computeThroughput(pd []ProcessData, count int) float64 {
+	var last int64
+	for _, proc := range pd {
+		if proc.ExitTime > last {
+			last = proc.ExitTime
+		}
+	}
+	if last == 0 {
+		return 0
+	}
+	return float64(count) / float64(last)
+}
+
+// ErrHorizon stops a simulation that reached SchedulerOptions.Horizon.
+var ErrHorizon = errors.New("horizon reached")
+
+// ErrTickLimit stops a simulation still running after SchedulerOptions.MaxTicks ticks.
+var ErrTickLimit = errors.New("tick limit")
+
+// pastHorizon returns ErrHorizon once a simulation has covered the simulated time up to the
+// configured horizon, or ErrTickLimit once it has run the maximum number of ticks without
+// finishing.
+func (o SchedulerOptions) pastHorizon(time int64) error {
+	if o.Horizon > 0 && time >= o.Horizon {
+		return ErrHorizon
+	}
+	if o.MaxTicks > 0 && time >= o.MaxTicks {
+		return fmt.Errorf("%w: simulation exceeded %d ticks, possible non-terminating schedule", ErrTickLimit, o.MaxTicks)
+	}
+	return nil
+}
+
+// unfinishedNotes lists the processes left with burst to run by a simulation stopped early. With
+// excludeNeverRun the ones that never ran are counted on one line instead, as outputTable leaves
+// them out of the table.
+func unfinishedNotes(processes []Process, pd []ProcessData, excludeNeverRun bool) []string {
+	var (
+		notes    []string
+		neverRan []string
+	)
+	for i, proc := range pd {
+		switch {
+		case proc.ExitTime != 0 || proc.Remaining == 0:
+		case excludeNeverRun && proc.FirstRun < 0:
+			neverRan = append(neverRan, fmt.Sprintf("P%d", processes[i].ProcessID))
+		default:
+			note := fmt.Sprintf("P%d unfinished with %d burst remaining", processes[i].ProcessID, proc.Remaining)
+			if proc.State == StateBlocked {
+				note += ", blocked on I/O"
+			}
+			notes = append(notes, note)
+		}
+	}
+	if len(neverRan) > 0 {
+		notes = append(notes, fmt.Sprintf("Excluded %d processes that never ran: %s", len(neverRan), strings.Join(neverRan, ", ")))
+	}
+	return notes
+}
+
+// getNextProcess returns the process after current in round-robin order that is ready to run at
+// time: released, unfinished and not blocked on I/O, or -1 if there is none.
+func getNextProcess(pd []ProcessData, proc []Process, blocker ioBlocker, current int, time int64) int {
+	counter, max := 0, len(proc) // intiate variables
+	current++
+	if current >= max {
+		current = 0
+	}
+
+	for counter < max {
+		if pd[current].ExitTime == 0 && releaseTime(proc[current]) <= time && !blocker.blocked(current, time) { // if the process isn't done, has been released and isn't doing I/O
+			return current
+		} else {
+			if current < (max - 1) { // increment to next one
+				current++
+			} else {
+				current = 0
+			}
+			counter++
+		}
+	}
+	return -1 // if all jobs are done
+
+}
+
+// RRSchedule outputs a round-robin schedule that switches processes every opts.Quantum ticks.
+// When no released process is left to run the CPU idles until the next release, and the idle
+// ticks are reported.
+//
+// With opts.RROverhead the dispatcher spends the first ticks of every quantum, including one that
+// continues the same process, before the process does any work; the overhead ticks are spread so
+// that n quanta spend round(n·quantum·overhead) in total, and count as the process's wait.
+//
+// A process that starts an I/O burst (see Process.Bursts) gives up the rest of its quantum without
+// losing any work, and rejoins the round once its I/O is done.
+//
+// A negative opts.Quantum schedules nothing: the result only carries an ErrInvalidArgs error in Err.
+func RRSchedule(ctx context.Context, w io.Writer, title string, processes []Process, opts SchedulerOptions) ScheduleResult {
+	if opts.Quantum < 0 {
+		return ScheduleResult{Title: title, Err: fmt.Errorf("%w: round-robin quantum must be at least 1, got %d", ErrInvalidArgs, opts.Quantum)}
+	}
+	var (
+		lostWork  int64
+		cancelErr error
+		gantt     = make([]TimeSlice, 0)
+	)
+	quantum := 0
+
+	TempProcesses := make([]Process, len(processes)) // make new array to manipulate without affecting parent
+	copy(TempProcesses, processes)
+
+	pd := make([]ProcessData, len(TempProcesses)) // new array to keep track of process data
+	for i := range pd {
+		pd[i] = ProcessData{TotalWait: 0, TAround: 0, ExitTime: 0, FirstRun: -1}
+	}
+
+	clock := opts.clock()
+	blocker := newIOBlocker(processes, pd)
+	var time, start int64 = clock.Now(), clock.Now()           // used to keep track of the current time
+	var dispatched int64                                       // work done by the current process since it was dispatched
+	current := getNextProcess(pd, processes, blocker, 0, time) // keep track of current process being handled, -1 while idle
+	last := 0                                                  // the process that ran last, where the round robin resumes after idling
+	var idle int64                                             // ticks with no released process to run
+	var burndown []BurndownTick                                // see SchedulerOptions.Burndown
+	var overhead, overheadLeft, quanta int64                   // dispatcher ticks in total and left in this quantum, quanta started
+	startQuantum := func() {
+		quanta++
+		perQuantum := float64(opts.quantum()) * opts.RROverhead
+		overheadLeft = int64(math.Round(float64(quanta)*perQuantum) - math.Round(float64(quanta-1)*perQuantum))
+	}
+	if current >= 0 {
+		startQuantum()
+	}
+	for !CheckIfDone(pd) {
+		if cancelErr = checkCancelled(ctx, time); cancelErr != nil {
+			break
+		}
+		if cancelErr = opts.pastHorizon(time - 1); cancelErr != nil { // the last tick worked ended at time-1
+			break
+		}
+		blocked := false              // the current process started an I/O burst at time
+		for index, proc := range pd { // at the start of the each cycle
+			if arrived(processes[index], time-1) { // if the process had arrived when the tick just worked started
+				if index != current && proc.ExitTime == 0 && !blocker.blocked(index, time-1) { // if it is not currently being worked, nor doing I/O
+					pd[index].TotalWait += 1 //increase wait time by one
+				} else if index != current {
+					continue // done, or blocked on I/O
+				} else if overheadLeft > 0 { // the dispatcher holds the CPU
+					overheadLeft--
+					overhead++
+					pd[index].TotalWait++
+				} else if index == current { // if the process is currently being worked on
+					if dispatched == 0 { // the first tick since dispatch, which started at time-1
+						pd[index].Laxity = laxity(processes[index], time-1, TempProcesses[index].BurstDuration)
+					}
+					TempProcesses[index].BurstDuration--
+					dispatched++
+					if pd[index].FirstRun < 0 {
+						pd[index].FirstRun = time - 1 // the tick just worked started at time-1
+					}
+					if TempProcesses[index].BurstDuration == 0 {
+						pd[index].ExitTime = time
+					} else {
+						blocked = blocker.ran(index, time, TempProcesses[index].BurstDuration)
+					}
+				}
+			}
+		}
+
+		preempted := -1
+		if current < 0 { // idle, until some process is released
+			if next := getNextProcess(pd, processes, blocker, last, time); next >= 0 {
+				quantum = 1
+				start = time
+				current = next
+				startQuantum()
+			}
+		} else if quantum < opts.quantum() && pd[current].ExitTime == 0 && !blocked { // if under the time quantum and has not finished
+			quantum++
+		} else {
+			quantum = 1
+			next := getNextProcess(pd, processes, blocker, current, time) // get the next index in the round robin, -1 if none is ready
+			if next != current && pd[current].ExitTime == 0 && !blocked {
+				preempted = current
+			}
+			if next != current { // if the new pid is not the same as the current update gantt
+				gantt = append(gantt, TimeSlice{
+					PID:   processes[current].ProcessID,
+					Start: start,
+					Stop:  time,
+				})
+				opts.emitSlice(gantt[len(gantt)-1])
+				if preempted >= 0 {
+					lost := preemptionPenalty(dispatched, opts.PreemptPenalty)
+					TempProcesses[current].BurstDuration += lost
+					pd[current].LostWork += lost
+					lostWork += lost
+				}
+				dispatched = 0
+				start = time
+				last = current
+				current = next
+			}
+			if current >= 0 {
+				startQuantum()
+			}
+		}
+		if !CheckIfDone(pd) { // the last pass only accounts for the final tick
+			if current < 0 {
+				idle++
+			}
+			opts.traceTick(title, time, processes, current, preempted, func(i int) bool {
+				return pd[i].ExitTime == 0 && releaseTime(processes[i]) <= time && !blocker.blocked(i, time)
+			})
+		}
+		burndown = opts.burndownTick(burndown, time, len(processes), func(i int) int64 { return TempProcesses[i].BurstDuration })
+		time = clock.Advance()
+	}
+
+	if cancelErr != nil && current >= 0 && time-1 > start { // close the slice that was running when cancelled
+		gantt = append(gantt, TimeSlice{
+			PID:   processes[current].ProcessID,
+			Start: start,
+			Stop:  time - 1,
+		})
+		opts.emitSlice(gantt[len(gantt)-1])
+	}
+	for i := range pd {
+		if pd[i].ExitTime == 0 {
+			pd[i].Remaining = TempProcesses[i].BurstDuration
+		}
+	}
+
+	res := tickResult(title, processes, pd, gantt, time-1, opts, cancelErr) // the loop counts one tick past the last one worked
+	res.LostWork = lostWork
+	res.IdleTicks = idle
+	res.OverheadTicks = overhead
+	res.Burndown = burndown
+	res.Utilization = utilization(gantt, overhead, res.StoppedAt)
+	if hasDeadlines(processes) {
+		res.Deadlines = checkDeadlines(processes, pd)
+		res.Notes = append(res.Notes, deadlineNotes(res.Deadlines, opts.quantum())...)
+	}
+	outputResult(w, opts, res)
+	return res
+}
+
+//endregion
+
+//region Output helpers
+
+func outputTitle(w io.Writer, title string) {
+	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
+	_, _ = fmt.Fprintln(w, strings.Repeat(" ", len(title)/2), title)
+	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
+}
+
+// ganttCellWidth is how many characters a slice's cell takes between the borders of the Gantt
+// chart, unless its label needs more.
+const ganttCellWidth = 7
+
+// outputGantt renders the Gantt chart with every idle gap, from t=0, as a slice labeled "idle".
+// Each label is centred in its cell, and the time axis under the chart starts every slice's
+// start, and the last slice's stop, at the column of the border it falls on.
+func outputGantt(w io.Writer, gantt []TimeSlice) {
+	gantt = withIdle(gantt)
+	var (
+		chart   = []byte("|")
+		borders = []int{0} // column of each border
+	)
+	for i := range gantt {
+		pid := fmt.Sprint(gantt[i].PID)
+		if gantt[i].PID == IdlePID {
+			pid = "idle"
+		}
+		width := ganttCellWidth
+		if len(pid)+2 > width {
+			width = len(pid) + 2
+		}
+		left := (width - len(pid)) / 2
+		chart = append(chart, strings.Repeat(" ", left)+pid+strings.Repeat(" ", width-left-len(pid))+"|"...)
+		borders = append(borders, len(chart)-1)
+	}
+
+	var axis []byte
+	for i, column := range borders {
+		if len(gantt) == 0 {
+			break // nothing ran, so there is no time to show
+		}
+		t := gantt[len(gantt)-1].Stop
+		if i < len(gantt) {
+			t = gantt[i].Start
+		}
+		if len(axis) > 0 && len(axis) >= column {
+			axis = append(axis, ' ') // the previous time ran past this border
+		}
+		for len(axis) < column {
+			axis = append(axis, ' ')
+		}
+		axis = append(axis, fmt.Sprint(t)...)
+	}
+	_, _ = fmt.Fprintln(w, "Gantt schedule")
+	_, _ = fmt.Fprintln(w, string(chart))
+	_, _ = fmt.Fprintf(w, "%s\n\n", axis)
+}
+
+// hasReleaseJitter reports whether any process is released later than it arrives.
+func hasReleaseJitter(processes []Process) bool {
+	for i := range processes {
+		if processes[i].ReleaseJitter != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// weight returns the process's weight, counting an unset zero as 1.
+func (p Process) weight() int64 {
+	if p.Weight == 0 {
+		return 1
+	}
+	return p.Weight
+}
+
+// hasWeights reports whether any process has a weight other than the default 1.
+func hasWeights(processes []Process) bool {
+	for i := range processes {
+		if processes[i].weight() != 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// tableColumns are the optional columns of a schedule table.
+type tableColumns struct {
+	release  bool // the effective release time, when any process has jitter
+	weight   bool
+	deadline bool // the deadline and whether it was missed, when any process has a deadline
+	laxity   bool // the laxity at the latest dispatch, when any process has a deadline
+}
+
+func scheduleColumns(processes []Process, opts SchedulerOptions) tableColumns {
+	deadlines := hasDeadlines(processes)
+	return tableColumns{
+		release:  hasReleaseJitter(processes) || opts.wantsColumn("Release"),
+		weight:   opts.ShowWeight || opts.wantsColumn("Weight"),
+		deadline: deadlines || opts.wantsColumn("Deadline", "Missed"),
+		laxity:   deadlines || opts.wantsColumn("Laxity"),
+	}
+}
+
+// scheduleHeader returns the schedule table columns, including the optional ones requested.
+func scheduleHeader(cols tableColumns) []string {
+	header := []string{"ID", "Priority"}
+	if cols.weight {
+		header = append(header, "Weight")
+	}
+	header = append(header, "Burst", "Arrival")
+	if cols.release {
+		header = append(header, "Release")
+	}
+	header = append(header, "Wait", "Response", "Turnaround", "Norm.TA", "Exit")
+	if cols.deadline {
+		header = append(header, "Deadline", "Missed")
+	}
+	if cols.laxity {
+		header = append(header, "Laxity")
+	}
+	return header
+}
+
+// scheduleRow returns the schedule table row for a process matching scheduleHeader.
+func scheduleRow(p Process, proc ProcessData, cols tableColumns, turnaround int64) []string {
+	row := []string{
+		fmt.Sprint(p.ProcessID),
+		formatPriority(p.Priority),
+	}
+	if cols.weight {
+		row = append(row, fmt.Sprint(p.weight()))
+	}
+	row = append(row,
+		fmt.Sprint(p.BurstDuration),
+		fmt.Sprint(p.ArrivalTime),
+	)
+	if cols.release {
+		row = append(row, fmt.Sprint(releaseTime(p)))
+	}
+	row = append(row,
+		fmt.Sprint(proc.TotalWait),
+		responseCell(p, proc),
+		exitedCell(proc, turnaround),
+		normTurnaroundCell(p, proc, turnaround),
+		exitedCell(proc, proc.ExitTime),
+	)
+	if cols.deadline {
+		row = append(row, deadlineCell(p), missedCell(p, proc))
+	}
+	if cols.laxity {
+		row = append(row, laxityCell(p, proc))
+	}
+	return row
+}
+
+// exitedCell is value, a process's turnaround or exit, or "-" if it never exited and so has none.
+func exitedCell(proc ProcessData, value int64) string {
+	if proc.ExitTime == 0 {
+		return "-"
+	}
+	return fmt.Sprint(value)
+}
+
+// responseCell is a process's response time, from arrival to first run, or "-" if it never ran.
+func responseCell(p Process, proc ProcessData) string {
+	if proc.FirstRun < 0 {
+		return "-"
+	}
+	return fmt.Sprint(proc.FirstRun - p.ArrivalTime)
+}
+
+// normTurnaroundCell is a process's normalized turnaround, its turnaround over its burst, or "-"
+// if it never exited.
+func normTurnaroundCell(p Process, proc ProcessData, turnaround int64) string {
+	if proc.ExitTime == 0 || p.BurstDuration <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f", float64(turnaround)/float64(p.BurstDuration))
+}
+
+// outputSchedule renders the result's table with its averages in the footer.
+func outputSchedule(w io.Writer, res ScheduleResult) {
+	_, _ = fmt.Fprintln(w, "Schedule table")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(res.Header)
+	table.AppendBulk(res.Rows)
+	footer := make([]string, len(res.Header))
+	for i, column := range res.Header { // each summary sits under the column it summarises
+		switch column {
+		case "Wait":
+			footer[i] = fmt.Sprintf("Average\n%.2f", res.AvgWait)
+		case "Response":
+			footer[i] = fmt.Sprintf("Average\n%.2f", res.AvgResponse)
+		case "Turnaround":
+			footer[i] = fmt.Sprintf("Average\n%.2f", res.AvgTurnaround)
+		case "Norm.TA":
+			footer[i] = fmt.Sprintf("Average\n%.2f", res.AvgNormTurnaround)
+		case "Exit":
+			footer[i] = fmt.Sprintf("Throughput\n%.2f/t", res.Throughput)
+		case "Missed":
+			footer[i] = fmt.Sprintf("Missed\n%d", res.MissedDeadlines)
+		}
+	}
+	table.SetFooter(footer)
+	table.Render()
+}
+
+// outputPlainSchedule renders the schedule table like outputSchedule but without any borders:
+// right-aligned columns separated by two spaces and a single averages line. Unlike tablewriter's
+// styling it only depends on this package, so it is what the golden tests compare against.
+func outputPlainSchedule(w io.Writer, res ScheduleResult) {
+	_, _ = fmt.Fprintln(w, "Schedule table")
+	writePlainTable(w, res.Header, res.Rows)
+	_, _ = fmt.Fprintf(w, "Average wait %.2f, average turnaround %.2f", res.AvgWait, res.AvgTurnaround)
+	if hasColumn(res.Header, "Norm.TA") {
+		_, _ = fmt.Fprintf(w, " (normalized %.2f)", res.AvgNormTurnaround)
+	}
+	_, _ = fmt.Fprintf(w, ", throughput %.2f/t", res.Throughput)
+	if hasColumn(res.Header, "Missed") {
+		_, _ = fmt.Fprintf(w, ", missed deadlines %d", res.MissedDeadlines)
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+// hasColumn reports whether the table header has the column.
+func hasColumn(header []string, column string) bool {
+	for _, name := range header {
+		if name == column {
+			return true
+		}
+	}
+	return false
+}
+
+// writePlainTable writes the header and rows as right-aligned columns separated by two spaces.
+func writePlainTable(w io.Writer, header []string, rows [][]string) {
+	widths := make([]int, len(header))
+	for _, row := range append([][]string{header}, rows...) {
+		for i, cell := range row {
+			if len(cell) > widths[i] {
+				widths[i] = len(cell)
+			}
+		}
+	}
+	for _, row := range append([][]string{header}, rows...) {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = fmt.Sprintf("%*s", widths[i], cell)
+		}
+		_, _ = fmt.Fprintln(w, strings.Join(cells, "  "))
+	}
+}
+
+// outputFormats are the accepted values of SchedulerOptions.Format.
+var outputFormats = []string{"table", "dot", "plain", "latex", "json", "csv", "svg", "mermaid"}
+
+func isOutputFormat(format string) bool {
+	for _, f := range outputFormats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// outputResult renders a schedule result in the configured format.
+func outputResult(w io.Writer, opts SchedulerOptions, res ScheduleResult) {
+	if opts.aggregateOnly {
+		return
+	}
+	switch opts.Format {
+	case "dot":
+		outputDOT(w, res)
+	case "latex":
+		outputLaTeX(w, res)
+	case "mermaid":
+		outputMermaid(w, res)
+	case "json", "csv", "svg":
+		// one document covers every result, see writeResults
+	default:
+		outputTable(w, opts, res)
+	}
+}
+
+// outputDOT renders the Gantt chart as a Graphviz digraph: one node per time slice labeled with
+// its PID and interval, and an edge between consecutive slices labeled with the switch time.
+func outputDOT(w io.Writer, res ScheduleResult) {
+	_, _ = fmt.Fprintf(w, "digraph %q {\n", res.Title)
+	_, _ = fmt.Fprintln(w, "\trankdir=LR;")
+	_, _ = fmt.Fprintln(w, "\tnode [shape=box];")
+	for i, slice := range res.Gantt {
+		_, _ = fmt.Fprintf(w, "\ts%d [label=\"P%d\\n[%d, %d)\"];\n", i, slice.PID, slice.Start, slice.Stop)
+	}
+	for i := 1; i < len(res.Gantt); i++ {
+		_, _ = fmt.Fprintf(w, "\ts%d -> s%d [label=\"t=%d\"];\n", i-1, i, res.Gantt[i].Start)
+	}
+	_, _ = fmt.Fprintln(w, "}")
+}
+
+// outputTable renders a schedule result as text: title, Gantt chart, table and any optional sections.
+func outputTable(w io.Writer, opts SchedulerOptions, res ScheduleResult) {
+	outputTitle(w, res.Title)
+	outputBacklog(w, opts.Backlog)
+	if opts.GanttScale > 0 {
+		RenderProportionalGantt(w, res.Gantt, opts.GanttScale)
+	} else {
+		outputGantt(w, res.Gantt)
+	}
+	// the table only lists the columns and rows asked for, the averages still cover every process
+	shown := selectColumns(res, opts.Columns)
+	if opts.ExcludeNeverRun {
+		rows := shown.Rows
+		shown.Rows = make([][]string, 0, len(rows))
+		for i, row := range rows {
+			if res.Data[i].ExitTime != 0 || res.Data[i].FirstRun >= 0 {
+				shown.Rows = append(shown.Rows, row)
+			}
+		}
+	}
+	if opts.Format == "plain" {
+		outputPlainSchedule(w, shown)
+	} else {
+		outputSchedule(w, shown)
+	}
+	_, _ = fmt.Fprintf(w, "Average response: %.2f (burst-weighted %.2f)\n", res.AvgResponse, res.WeightedResponse)
+	if res.StoppedAt > 0 {
+		_, _ = fmt.Fprintf(w, "CPU utilization: %.2f%%\n", 100*res.Utilization)
+	}
+	_, _ = fmt.Fprintf(w, "Context switches: %d\n", res.ContextSwitches)
+	if res.Completed() > 0 {
+		_, _ = fmt.Fprintf(w, "Fairness index: %.2f (Jain's, over turnaround; 1 is perfectly fair)\n", res.FairnessIndex)
+	}
+	outputExplain(w, opts, res)
+	outputCumulative(w, opts, res.Cumulative)
+	outputCohorts(w, opts, res)
+	outputLostWork(w, opts, res.LostWork)
+	if res.IdleTicks > 0 {
+		_, _ = fmt.Fprintf(w, "CPU idle: %d ticks\n", res.IdleTicks)
+	}
+	if res.OverheadTicks > 0 && res.StoppedAt > 0 {
+		_, _ = fmt.Fprintf(w, "Dispatcher overhead: %d ticks, effective utilization %.1f%%\n", res.OverheadTicks, 100*res.Utilization)
+	}
+	if res.MakespanGap.Makespan > 0 {
+		_, _ = fmt.Fprintln(w, res.MakespanGap)
+	}
+	if res.Little.InSystem > 0 {
+		_, _ = fmt.Fprintln(w, res.Little)
+	}
+	for _, note := range res.Notes {
+		_, _ = fmt.Fprintln(w, note)
+	}
+	outputEvents(w, opts, res.Gantt, res.Data)
+	switch {
+	case errors.Is(res.Err, ErrHorizon):
+		_, _ = fmt.Fprintf(w, "Horizon t=%d reached with %d processes completed: schedule is partial\n", res.StoppedAt, res.Completed())
+	case errors.Is(res.Err, ErrTickLimit):
+		_, _ = fmt.Fprintf(w, "Stopped at t=%d with %d processes completed: %v\n", res.StoppedAt, res.Completed(), res.Err)
+	case errors.Is(res.Err, context.DeadlineExceeded):
+		_, _ = fmt.Fprintf(w, "Timed out at t=%d with %d processes completed: schedule is partial\n", res.StoppedAt, res.Completed())
+	case res.Err != nil:
+		_, _ = fmt.Fprintf(w, "Interrupted at t=%d: schedule is partial\n", res.StoppedAt)
+	}
+}
+
+// outputBacklog reports how many processes started out already waiting, if any.
+func outputBacklog(w io.Writer, backlog int) {
+	if backlog > 0 {
+		_, _ = fmt.Fprintf(w, "Backlog: %d processes waiting at t=0\n", backlog)
+	}
+}
+
+// outputLostWork reports the total work redone due to preemption when a penalty is configured.
+func outputLostWork(w io.Writer, opts SchedulerOptions, lost int64) {
+	if opts.PreemptPenalty == 0 {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "Lost work: %d (preempt penalty %.2f)\n", lost, opts.PreemptPenalty)
+}
+
+// outputEvents prints the event log of a schedule when requested.
+func outputEvents(w io.Writer, opts SchedulerOptions, gantt []TimeSlice, pd []ProcessData) {
+	if !opts.Events {
+		return
+	}
+	_, _ = fmt.Fprintln(w, "Event log")
+	for _, event := range eventLog(gantt, pd) {
+		_, _ = fmt.Fprintln(w, event)
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+// eventLog turns a Gantt chart into a chronological list of dispatch, preemption, I/O and
+// completion events. A slice ends in a completion when some process exits at its stop time, since
+// only the running process can exit on a single CPU, and in I/O when its process blocks then; any
+// other slice boundary is a preemption by the next slice.
+func eventLog(gantt []TimeSlice, pd []ProcessData) []string {
+	exits := make(map[int64]bool, len(pd))
+	blocks := make(map[TimeSlice]int64) // the I/O each process starts at a time, keyed without its stop
+	for _, proc := range pd {
+		exits[proc.ExitTime] = true
+		for _, span := range proc.IO {
+			blocks[TimeSlice{PID: span.PID, Start: span.Start}] = span.Stop
+		}
+	}
+
+	var (
+		events    []string
+		preempted bool // the running process was preempted, so the next dispatch is already logged
+	)
+	for i, slice := range gantt {
+		if slice.Start == slice.Stop {
+			continue // nothing ran
+		}
+		if !preempted {
+			events = append(events, fmt.Sprintf("t=%d P%d dispatched", slice.Start, slice.PID))
+		}
+		preempted = false
+
+		until, blocked := blocks[TimeSlice{PID: slice.PID, Start: slice.Stop}]
+		switch {
+		case blocked:
+			events = append(events, fmt.Sprintf("t=%d P%d blocked on I/O until t=%d", slice.Stop, slice.PID, until))
+		case exits[slice.Stop]:
+			events = append(events, fmt.Sprintf("t=%d P%d completed", slice.Stop, slice.PID))
+		case i+1 < len(gantt) && gantt[i+1].Start == slice.Stop && gantt[i+1].PID != slice.PID:
+			events = append(events, fmt.Sprintf("t=%d P%d preempted by P%d", slice.Stop, slice.PID, gantt[i+1].PID))
+			preempted = true
+		}
+	}
+
+	return events
+}
+
+//endregion
+
+//region Loading processes.
+
+var ErrInvalidArgs = errors.New("invalid args")
+
+// processColumns names the columns of a process file in order; every column after the burst
+// duration is optional.
+var processColumns = []string{"ID", "burst", "arrival", "priority", "release jitter", "weight", "deadline", "bursts"}
+
+func loadProcesses(r io.Reader) ([]Process, error) {
+	processes, _, err := readProcesses(r)
+	return processes, err
+}
+
+// readProcesses loads processes like loadProcesses and also returns the anomalies it tolerated
+// on the way (see checkAnomalies).
+func readProcesses(r io.Reader) ([]Process, []string, error) {
+	return readProcessesWith(r, loadOptions{})
+}
+
+// commentChar starts a comment line in a process file, e.g. "# three CPU-bound jobs". Only a line
+// beginning with it is a comment, so it can still appear inside a quoted cell.
+const commentChar = '#'
+
+// loadOptions tunes how readProcessesWith reads a process file.
+type loadOptions struct {
+	// renumber makes a process ID repeating an earlier row's no anomaly: every such row gets a
+	// fresh ID, counting up from the largest in the file in row order, so the Gantt charts and
+	// tables tell the processes apart.
+	renumber bool
+	// comma separates the cells of a row, e.g. '\t' for TSV; zero means ','.
+	comma rune
+}
+
+// readProcessesWith is readProcesses with the given options.
+func readProcessesWith(r io.Reader, opts loadOptions) ([]Process, []string, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1 // rows may leave off any optional columns at the end
+	cr.Comment = commentChar
+	if opts.comma != 0 {
+		cr.Comma = opts.comma
+	}
+	var (
+		rows  [][]string
+		lines []int // the line each row starts on, so errors name the file's rows despite comments
+	)
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: reading CSV", err)
+		}
+		line, _ := cr.FieldPos(0)
+		rows, lines = append(rows, record), append(lines, line)
+	}
+
+	var anomalies []string
+	if len(rows) > 0 && isHeaderRow(rows[0]) {
+		anomalies = append(anomalies, fmt.Sprintf("row %d: header row skipped", lines[0]))
+		rows, lines = rows[1:], lines[1:]
+	}
+
+	var (
+		seen       = make(map[int64]int, len(rows))
+		duplicates []int // with renumber, the rows to give fresh IDs
+		maxID      int64
+	)
+	processes := make([]Process, len(rows))
+	for i := range rows {
+		row := lines[i]
+		if len(rows[i]) < 2 {
+			return nil, nil, fmt.Errorf("%w: row %d must have at least a process ID and a burst duration", ErrInvalidArgs, row)
+		}
+		if len(rows[i]) > len(processColumns) {
+			return nil, nil, fmt.Errorf("%w: row %d has %d columns, but a process has at most %d: %s",
+				ErrInvalidArgs, row, len(rows[i]), len(processColumns), strings.Join(processColumns, ", "))
+		}
+		var err error
+		// cellErr keeps the error of the row's first cell that failed to parse, counting columns from 1
+		cellErr := func(col int, cerr error) {
+			if err == nil && cerr != nil {
+				err = fmt.Errorf("%w: row %d, column %d: %w", ErrInvalidArgs, row, col, cerr)
+			}
+		}
+		intCell := func(col int) int64 {
+			v, cerr := strToInt(rows[i][col-1])
+			cellErr(col, cerr)
+			return v
+		}
+		processes[i].ProcessID = intCell(1)
+		processes[i].BurstDuration = intCell(2)
+		// optional columns that are missing or left blank keep their zero default
+		if len(rows[i]) >= 3 && strings.TrimSpace(rows[i][2]) != "" {
+			processes[i].ArrivalTime = intCell(3)
+		}
+		if len(rows[i]) >= 4 && strings.TrimSpace(rows[i][3]) != "" {
+			priority, cerr := strToFloat(rows[i][3])
+			cellErr(4, cerr)
+			processes[i].Priority = priority
+		} else if len(rows[i]) >= 4 {
+			anomalies = append(anomalies, fmt.Sprintf("row %d: empty priority defaulted to 0", row))
+		}
+		if len(rows[i]) >= 5 && strings.TrimSpace(rows[i][4]) != "" {
+			processes[i].ReleaseJitter = intCell(5)
+		}
+		processes[i].Weight = 1
+		if len(rows[i]) >= 6 && strings.TrimSpace(rows[i][5]) != "" {
+			processes[i].Weight = intCell(6)
+		}
+		if len(rows[i]) >= 7 && strings.TrimSpace(rows[i][6]) != "" {
+			processes[i].Deadline = intCell(7)
+		}
+		if len(rows[i]) >= 8 && strings.TrimSpace(rows[i][7]) != "" {
+			bursts, cerr := parseBursts(rows[i][7])
+			cellErr(8, cerr)
+			if cerr == nil && cpuTime(bursts) != processes[i].BurstDuration {
+				cellErr(8, fmt.Errorf("CPU bursts %q add up to %d, but the burst duration is %d",
+					rows[i][7], cpuTime(bursts), processes[i].BurstDuration))
+			}
+			processes[i].Bursts = bursts
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if first, ok := seen[processes[i].ProcessID]; ok {
+			if opts.renumber {
+				duplicates = append(duplicates, i)
+				continue
+			}
+			anomalies = append(anomalies, fmt.Sprintf("row %d: process ID %d duplicates row %d", row, processes[i].ProcessID, first))
+		} else {
+			seen[processes[i].ProcessID] = row
+		}
+		if processes[i].ProcessID > maxID {
+			maxID = processes[i].ProcessID
+		}
+	}
+	for _, i := range duplicates {
+		maxID++
+		slog.Info("renumbered duplicate process ID", "row", lines[i], "id", processes[i].ProcessID, "new_id", maxID)
+		processes[i].ProcessID = maxID
+	}
+
+	return processes, anomalies, nil
+}
+
+// isHeaderRow reports whether a row is a header such as spreadsheets export, e.g.
+// "ProcessID,BurstDuration,ArrivalTime,Priority": none of its cells is a number. A row with any
+// numeric cell is data, so a malformed data row still fails to load rather than being skipped.
+func isHeaderRow(row []string) bool {
+	for _, cell := range row {
+		if _, err := strconv.ParseFloat(strings.TrimSpace(cell), 64); err == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// writeProcesses writes processes in the CSV format loadProcesses reads, omitting each optional
+// column after the priority unless it or a later column is needed: the bursts column when no
+// process has bursts, the deadline column when no process has a deadline either, the weight
+// column when every weight is 1 as well, and the release jitter column when no process has
+// jitter either.
+func writeProcesses(w io.Writer, processes []Process) error {
+	cw := csv.NewWriter(w)
+	showBursts := false
+	for _, p := range processes {
+		showBursts = showBursts || len(p.Bursts) > 0
+	}
+	showDeadline := showBursts || hasDeadlines(processes)
+	showWeight := showDeadline || hasWeights(processes)
+	showRelease := showWeight || hasReleaseJitter(processes)
+	for _, p := range processes {
+		row := []string{
+			fmt.Sprint(p.ProcessID),
+			fmt.Sprint(p.BurstDuration),
+			fmt.Sprint(p.ArrivalTime),
+			formatPriority(p.Priority),
+		}
+		if showRelease {
+			row = append(row, fmt.Sprint(p.ReleaseJitter))
+		}
+		if showWeight {
+			row = append(row, fmt.Sprint(p.weight()))
+		}
+		if showDeadline {
+			row = append(row, fmt.Sprint(p.Deadline))
+		}
+		if showBursts {
+			row = append(row, formatBursts(p.Bursts))
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// validateProcesses reports every process that can't be scheduled sensibly: non-positive bursts,
+// negative arrivals, release jitter or weights, and duplicate process IDs.
+func validateProcesses(processes []Process) error {
+	var (
+		errs []error
+		seen = make(map[int64]bool, len(processes))
+	)
+	for _, p := range processes {
+		errs = append(errs, valueErrors(p)...)
+		if seen[p.ProcessID] {
+			errs = append(errs, fmt.Errorf("process %d: duplicate process ID", p.ProcessID))
+		}
+		seen[p.ProcessID] = true
+	}
+	return errors.Join(errs...)
+}
+
+// checkSchedulable reports every process whose values the schedulers can't simulate, such as a
+// burst of 0, which would never exit. Unlike validateProcesses it tolerates duplicate IDs, which
+// loading already reports as an anomaly.
+func checkSchedulable(processes []Process) error {
+	var errs []error
+	for _, p := range processes {
+		errs = append(errs, valueErrors(p)...)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %w", ErrInvalidArgs, errors.Join(errs...))
+	}
+	return nil
+}
+
+// valueErrors reports each of the process's values that is out of range.
+func valueErrors(p Process) []error {
+	var errs []error
+	if p.BurstDuration <= 0 {
+		errs = append(errs, fmt.Errorf("process %d: burst duration must be positive, got %d", p.ProcessID, p.BurstDuration))
+	}
+	if p.ArrivalTime < 0 {
+		errs = append(errs, fmt.Errorf("process %d: arrival time must not be negative, got %d", p.ProcessID, p.ArrivalTime))
+	}
+	if p.ReleaseJitter < 0 {
+		errs = append(errs, fmt.Errorf("process %d: release jitter must not be negative, got %d", p.ProcessID, p.ReleaseJitter))
+	}
+	if p.Weight < 0 {
+		errs = append(errs, fmt.Errorf("process %d: weight must not be negative, got %d", p.ProcessID, p.Weight))
+	}
+	if p.Deadline < 0 {
+		errs = append(errs, fmt.Errorf("process %d: deadline must not be negative, got %d", p.ProcessID, p.Deadline))
+	}
+	if len(p.Bursts) > 0 && cpuTime(p.Bursts) != p.BurstDuration {
+		errs = append(errs, fmt.Errorf("process %d: CPU bursts add up to %d, but the burst duration is %d", p.ProcessID, cpuTime(p.Bursts), p.BurstDuration))
+	}
+	return errs
+}
+
+// strToInt parses an integer cell such as a burst duration.
+func strToInt(s string) (int64, error) {
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not an integer", s)
+	}
+	return i, nil
+}
+
+// strToFloat parses a finite number such as a priority.
+func strToFloat(s string) (float64, error) {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("%q is not a finite number", s)
+	}
+	return f, nil
+}
+
+// formatPriority prints a priority with as many decimals as it needs, so integer priorities
+// print without any.
+func formatPriority(priority float64) string {
+	return strconv.FormatFloat(priority, 'f', -1, 64)
+}
+
+//endregion
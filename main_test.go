@@ -1,207 +1,1622 @@
-package main
-
-import (
-	"bytes"
-	"errors"
-	"io"
-	"os"
-	"path"
-	"reflect"
-	"strings"
-	"testing"
-	"testing/iotest"
-)
-
-func TestFCFSSchedule(t *testing.T) {
-	t.Parallel()
-	type args struct {
-		processes []Process
-		title     string
-	}
-	tests := []struct {
-		name    string
-		args    args
-		wantOut string
-	}{
-		{
-			name: "default",
-			args: args{
-				processes: []Process{
-					{
-						ProcessID:     1,
-						ArrivalTime:   0,
-						BurstDuration: 5,
-						Priority:      2,
-					},
-					{
-						ProcessID:     2,
-						ArrivalTime:   3,
-						BurstDuration: 9,
-						Priority:      1,
-					},
-					{
-						ProcessID:     3,
-						ArrivalTime:   6,
-						BurstDuration: 6,
-						Priority:      3,
-					},
-				},
-				title: "First-come, First-serve",
-			},
-			wantOut: loadFixture(t, "fcfs_test.txt"),
-		},
-	}
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-			var w bytes.Buffer
-			FCFSSchedule(&w, tt.args.title, tt.args.processes)
-			if got := w.String(); got != tt.wantOut {
-				t.Errorf("FCFSSchedule() = %v, want %v", got, tt.wantOut)
-			}
-		})
-	}
-}
-
-func Test_loadProcesses(t *testing.T) {
-	t.Parallel()
-	type args struct {
-		r io.Reader
-	}
-	tests := []struct {
-		name    string
-		args    args
-		want    []Process
-		wantErr error
-	}{
-		{
-			name: "bad CSV",
-			args: args{
-				r: iotest.ErrReader(io.ErrUnexpectedEOF),
-			},
-			wantErr: io.ErrUnexpectedEOF,
-		},
-		{
-			name: "success",
-			args: args{
-				r: strings.NewReader(`1,5,0,2
-2,9,3,1
-3,6,3,3`),
-			},
-			want: []Process{
-				{
-					ProcessID:     1,
-					ArrivalTime:   0,
-					BurstDuration: 5,
-					Priority:      2,
-				},
-				{
-					ProcessID:     2,
-					ArrivalTime:   3,
-					BurstDuration: 9,
-					Priority:      1,
-				},
-				{
-					ProcessID:     3,
-					ArrivalTime:   3,
-					BurstDuration: 6,
-					Priority:      3,
-				},
-			},
-		},
-	}
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-			got, err := loadProcesses(tt.args.r)
-			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("loadProcesses() = %v, want %v", got, tt.want)
-			}
-			if !errors.Is(err, tt.wantErr) {
-				t.Errorf("error = %v, want %v", err, tt.wantErr)
-			}
-		})
-	}
-}
-
-func loadFixture(t *testing.T, p ...string) string {
-	b, err := os.ReadFile(path.Join(p...))
-	if err != nil {
-		t.Fail()
-	}
-
-	return string(b)
-}
-
-func Test_openProcessingFile1(t *testing.T) {
-	tmpFile, tErr := os.CreateTemp(t.TempDir(), "")
-	if tErr != nil {
-		t.Fatal(tErr)
-	}
-
-	type args struct {
-		args []string
-	}
-	tests := []struct {
-		name    string
-		args    args
-		want    *os.File
-		wantErr bool
-	}{
-		{
-			name: "success",
-			args: args{
-				args: []string{"binary_name", tmpFile.Name()},
-			},
-			want: tmpFile,
-		},
-		{
-			name: "not enough args",
-			args: args{
-				args: []string{"binary_name"},
-			},
-			wantErr: true,
-		},
-		{
-			name: "bad file",
-			args: args{
-				args: []string{"binary_name", "bad_file_name"},
-			},
-			wantErr: true,
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got, closeFn, err := openProcessingFile(tt.args.args...)
-			if (err != nil) != tt.wantErr {
-				t.Fatalf("openProcessingFile() error = %v, wantErr %v", err, tt.wantErr)
-			}
-			if err != nil {
-				return
-			}
-
-			if got == nil {
-				t.Fatal("file is unexpectedly nil")
-			}
-			if closeFn == nil {
-				t.Fatal("closeFn is unexpectedly nil")
-			}
-			t.Cleanup(closeFn)
-
-			f1, err := os.Stat(got.Name())
-			if err != nil {
-				t.Fatalf("Could not stat file: %v", got)
-			}
-			f2, err := os.Stat(tt.want.Name())
-			if err != nil {
-				t.Fatalf("Could not stat file: %v", tt.want)
-			}
-
-			if !os.SameFile(f1, f2) {
-				t.Fatal("files are not the same")
-			}
-		})
-	}
-}
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+func TestFCFSSchedule(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		processes []Process
+		title     string
+	}
+	tests := []struct {
+		name           string
+		args           args
+		wantOut        string
+		wantGantt      []TimeSlice
+		wantWait       float64
+		wantTurnaround float64
+	}{
+		{
+			name: "default",
+			args: args{
+				processes: []Process{
+					{
+						ProcessID:     1,
+						ArrivalTime:   0,
+						BurstDuration: 5,
+						Priority:      2,
+					},
+					{
+						ProcessID:     2,
+						ArrivalTime:   3,
+						BurstDuration: 9,
+						Priority:      1,
+					},
+					{
+						ProcessID:     3,
+						ArrivalTime:   6,
+						BurstDuration: 6,
+						Priority:      3,
+					},
+				},
+				title: "First-come, First-serve",
+			},
+			wantOut:        loadFixture(t, "fcfs_test.txt"),
+			wantGantt:      []TimeSlice{{PID: 1, Start: 0, Stop: 5}, {PID: 2, Start: 5, Stop: 14}, {PID: 3, Start: 14, Stop: 20}},
+			wantWait:       10.0 / 3,
+			wantTurnaround: 10,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			res := FCFSSchedule(context.Background(), &w, tt.args.title, tt.args.processes, SchedulerOptions{Format: "plain"})
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("FCFSSchedule() = %v, want %v", got, tt.wantOut)
+			}
+			if !reflect.DeepEqual(res.Gantt, tt.wantGantt) {
+				t.Errorf("FCFSSchedule() Gantt = %v, want %v", res.Gantt, tt.wantGantt)
+			}
+			if res.AvgWait != tt.wantWait || res.AvgTurnaround != tt.wantTurnaround {
+				t.Errorf("FCFSSchedule() averages = %v/%v, want %v/%v", res.AvgWait, res.AvgTurnaround, tt.wantWait, tt.wantTurnaround)
+			}
+		})
+	}
+}
+
+func TestFCFSScheduleArrivalOrder(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 3, BurstDuration: 2},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 4},
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 3},
+	}
+	input := append([]Process(nil), processes...)
+	res := FCFSSchedule(context.Background(), io.Discard, "FCFS", processes, SchedulerOptions{})
+
+	// textbook: P2 runs [0, 4), P3 [4, 7) after waiting 3, P1 [7, 9) after waiting 4
+	wantGantt := []TimeSlice{{PID: 2, Start: 0, Stop: 4}, {PID: 3, Start: 4, Stop: 7}, {PID: 1, Start: 7, Stop: 9}}
+	if !reflect.DeepEqual(res.Gantt, wantGantt) {
+		t.Errorf("Gantt = %v, want %v", res.Gantt, wantGantt)
+	}
+	for i, want := range []int64{4, 0, 3} {
+		if got := res.Data[i].TotalWait; got != want {
+			t.Errorf("P%d TotalWait = %d, want %d", processes[i].ProcessID, got, want)
+		}
+		if got := res.Rows[i][0]; got != fmt.Sprint(processes[i].ProcessID) {
+			t.Errorf("row %d is for P%s, want the input order's P%d", i, got, processes[i].ProcessID)
+		}
+	}
+	if res.AvgWait != 7.0/3 {
+		t.Errorf("AvgWait = %v, want %v", res.AvgWait, 7.0/3)
+	}
+	if !reflect.DeepEqual(processes, input) {
+		t.Errorf("FCFSSchedule() reordered its input to %v", processes)
+	}
+}
+
+// testSchedulers lists every scheduler for tests that must hold across algorithms.
+var testSchedulers = []struct {
+	name     string
+	schedule func(context.Context, io.Writer, string, []Process, SchedulerOptions) ScheduleResult
+}{
+	{name: "FCFS", schedule: FCFSSchedule},
+	{name: "SJF", schedule: NonPreemptiveSJFSchedule},
+	{name: "HRRN", schedule: HRRNSchedule},
+	{name: "SRTF", schedule: SRTFSchedule},
+	{name: "LRTF", schedule: LRTFSchedule},
+	{name: "SJF priority", schedule: SJFPrioritySchedule},
+	{name: "Priority", schedule: PrioritySchedule},
+	{name: "Arrival priority", schedule: ArrivalPreemptiveSchedule},
+	{name: "EDF", schedule: EDFSchedule},
+	{name: "RR", schedule: RRSchedule},
+	{name: "MLFQ", schedule: MLFQSchedule},
+	{name: "CFS", schedule: CFSSchedule},
+}
+
+// tickCapContext is a context that reports itself expired after its Err method has been polled
+// max times, giving simulations a hard cap on the ticks they can run regardless of wall-clock speed.
+type tickCapContext struct {
+	context.Context
+	polls, max int
+}
+
+func (c *tickCapContext) Err() error {
+	c.polls++
+	if c.polls > c.max {
+		return context.DeadlineExceeded
+	}
+	return nil
+}
+
+func TestSchedulersAllProcessesExit(t *testing.T) {
+	t.Parallel()
+	datasets := []struct {
+		name      string
+		processes []Process
+	}{
+		{
+			name: "example",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+				{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+				{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+			},
+		},
+		{
+			name: "simultaneous arrivals",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4, Priority: 1},
+				{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2, Priority: 1},
+				{ProcessID: 3, ArrivalTime: 0, BurstDuration: 3, Priority: 2},
+				{ProcessID: 4, ArrivalTime: 0, BurstDuration: 1, Priority: 3},
+			},
+		},
+		{
+			name: "first shortest finishes first",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, Priority: 3},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 7, Priority: 2},
+				{ProcessID: 3, ArrivalTime: 2, BurstDuration: 4, Priority: 1},
+			},
+		},
+	}
+	for _, sched := range testSchedulers {
+		for _, data := range datasets {
+			sched, data := sched, data
+			t.Run(sched.name+"/"+data.name, func(t *testing.T) {
+				t.Parallel()
+				var totalBurst, lastRelease int64
+				for _, p := range data.processes {
+					totalBurst += p.BurstDuration
+					if release := releaseTime(p); release > lastRelease {
+						lastRelease = release
+					}
+				}
+
+				ctx := &tickCapContext{Context: context.Background(), max: 1000}
+				res := sched.schedule(ctx, io.Discard, sched.name, data.processes, SchedulerOptions{})
+				if res.Err != nil {
+					t.Fatalf("simulation did not finish within the tick cap: %v", res.Err)
+				}
+				if len(res.Data) != len(data.processes) {
+					t.Fatalf("got data for %d processes, want %d", len(res.Data), len(data.processes))
+				}
+				for i, proc := range res.Data {
+					p := data.processes[i]
+					// a process can't exit before running its whole burst, nor after every burst ran back to back
+					if proc.ExitTime < releaseTime(p)+p.BurstDuration || proc.ExitTime > lastRelease+totalBurst {
+						t.Errorf("P%d ExitTime = %d, want within [%d, %d]", p.ProcessID, proc.ExitTime,
+							releaseTime(p)+p.BurstDuration, lastRelease+totalBurst)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestSchedulersWaitAccounting(t *testing.T) {
+	t.Parallel()
+	datasets := []struct {
+		name      string
+		processes []Process
+	}{
+		{
+			// the CPU idles over [2, 5) with nothing released
+			name: "idle gap",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, Priority: 1},
+				{ProcessID: 2, ArrivalTime: 5, BurstDuration: 3, Priority: 2},
+				{ProcessID: 3, ArrivalTime: 5, BurstDuration: 1, Priority: 1},
+			},
+		},
+		{
+			// P2 has arrived during the idle gap [2, 4) but isn't released until t=4
+			name: "idle with jitter",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, Priority: 1},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1, Priority: 1, ReleaseJitter: 3},
+			},
+		},
+	}
+	for _, sched := range testSchedulers {
+		for _, data := range datasets {
+			sched, data := sched, data
+			t.Run(sched.name+"/"+data.name, func(t *testing.T) {
+				t.Parallel()
+				res := sched.schedule(context.Background(), io.Discard, sched.name, data.processes, SchedulerOptions{})
+				for i, proc := range res.Data {
+					p := data.processes[i]
+					if want := proc.ExitTime - p.ArrivalTime - p.BurstDuration - proc.LostWork; proc.TotalWait != want {
+						t.Errorf("P%d TotalWait = %d, want exit - arrival - burst = %d", p.ProcessID, proc.TotalWait, want)
+					}
+				}
+			})
+		}
+	}
+
+	// by hand: P1 runs [0, 2), the CPU idles until t=5, P3 runs [5, 6) as the shorter and P2 [6, 9)
+	res := SRTFSchedule(context.Background(), io.Discard, "SRTF", datasets[0].processes, SchedulerOptions{})
+	for i, want := range []int64{0, 1, 0} {
+		if got := res.Data[i].TotalWait; got != want {
+			t.Errorf("SRTF P%d TotalWait = %d, want %d", i+1, got, want)
+		}
+	}
+}
+
+func TestSchedulersNonSequentialIDs(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 10, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 20, ArrivalTime: 1, BurstDuration: 3, Priority: 1},
+		{ProcessID: 30, ArrivalTime: 2, BurstDuration: 1, Priority: 3},
+	}
+	for _, sched := range testSchedulers {
+		sched := sched
+		t.Run(sched.name, func(t *testing.T) {
+			t.Parallel()
+			res := sched.schedule(context.Background(), io.Discard, sched.name, processes, SchedulerOptions{})
+			worked := make(map[int64]int64)
+			for _, slice := range res.Gantt {
+				worked[slice.PID] += slice.Stop - slice.Start
+			}
+			for _, p := range processes {
+				if worked[p.ProcessID] != p.BurstDuration {
+					t.Errorf("Gantt = %v, want P%d to run its burst of %d", res.Gantt, p.ProcessID, p.BurstDuration)
+				}
+			}
+			if len(worked) != len(processes) {
+				t.Errorf("Gantt = %v labels slices with PIDs other than the processes' IDs", res.Gantt)
+			}
+		})
+	}
+}
+
+func TestSchedulersSimultaneousArrival(t *testing.T) {
+	t.Parallel()
+	// both arrive at t=2: from that tick on each is available, to run or to wait
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 2, BurstDuration: 2, Priority: 1},
+		{ProcessID: 2, ArrivalTime: 2, BurstDuration: 2, Priority: 1},
+	}
+	for _, sched := range testSchedulers {
+		sched := sched
+		t.Run(sched.name, func(t *testing.T) {
+			t.Parallel()
+			res := sched.schedule(context.Background(), io.Discard, sched.name, processes, SchedulerOptions{})
+			if len(res.Gantt) == 0 || res.Gantt[0].Start != 2 {
+				t.Fatalf("Gantt = %v, want the first slice at t=2", res.Gantt)
+			}
+			for i, proc := range res.Data {
+				if proc.FirstRun < 2 {
+					t.Errorf("P%d FirstRun = %d, want no earlier than its arrival at 2", processes[i].ProcessID, proc.FirstRun)
+				}
+				if proc.TotalWait < proc.FirstRun-2 {
+					t.Errorf("P%d TotalWait = %d, want every tick from t=2 to its first run at %d counted", processes[i].ProcessID, proc.TotalWait, proc.FirstRun)
+				}
+			}
+		})
+	}
+}
+
+func TestSRTFScheduleSelection(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		want      []TimeSlice
+	}{
+		{
+			name: "first row exits first",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 4},
+				{ProcessID: 3, ArrivalTime: 2, BurstDuration: 1},
+			},
+			want: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 3, Start: 2, Stop: 3}, {PID: 2, Start: 3, Stop: 7}},
+		},
+		{
+			name: "first row arrives last",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 5, BurstDuration: 1},
+				{ProcessID: 2, ArrivalTime: 0, BurstDuration: 3},
+				{ProcessID: 3, ArrivalTime: 0, BurstDuration: 2},
+			},
+			want: []TimeSlice{{PID: 3, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 5}, {PID: 1, Start: 5, Stop: 6}},
+		},
+		{
+			name: "running process keeps ties",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2},
+				{ProcessID: 3, ArrivalTime: 1, BurstDuration: 2},
+			},
+			want: []TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 3, Stop: 5}, {PID: 3, Start: 5, Stop: 7}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			res := SRTFSchedule(context.Background(), io.Discard, "SRTF", tt.processes, SchedulerOptions{})
+			if !reflect.DeepEqual(res.Gantt, tt.want) {
+				t.Errorf("Gantt = %v, want %v", res.Gantt, tt.want)
+			}
+		})
+	}
+}
+
+func TestSJFPriorityScheduleSelection(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		want      []TimeSlice
+	}{
+		{
+			name: "least remaining first",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 1},
+				{ProcessID: 2, ArrivalTime: 0, BurstDuration: 5},
+				{ProcessID: 3, ArrivalTime: 0, BurstDuration: 3},
+				{ProcessID: 4, ArrivalTime: 0, BurstDuration: 4},
+			},
+			want: []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 3, Start: 1, Stop: 4}, {PID: 4, Start: 4, Stop: 8}, {PID: 2, Start: 8, Stop: 13}},
+		},
+		{
+			name: "identical processes by PID",
+			processes: []Process{
+				{ProcessID: 7, ArrivalTime: 0, BurstDuration: 2, Priority: 1},
+				{ProcessID: 3, ArrivalTime: 0, BurstDuration: 2, Priority: 1},
+				{ProcessID: 5, ArrivalTime: 0, BurstDuration: 2, Priority: 1},
+			},
+			want: []TimeSlice{{PID: 3, Start: 0, Stop: 2}, {PID: 5, Start: 2, Stop: 4}, {PID: 7, Start: 4, Stop: 6}},
+		},
+		{
+			name: "ties by priority then arrival",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 1, BurstDuration: 2, Priority: 1},
+				{ProcessID: 2, ArrivalTime: 0, BurstDuration: 3, Priority: 1},
+				{ProcessID: 3, ArrivalTime: 1, BurstDuration: 2, Priority: 2},
+			},
+			// at t=1 P2 has 2 left like P1 and P3, but only P3's priority beats it
+			want: []TimeSlice{{PID: 2, Start: 0, Stop: 1}, {PID: 3, Start: 1, Stop: 3}, {PID: 2, Start: 3, Stop: 5}, {PID: 1, Start: 5, Stop: 7}},
+		},
+		{
+			name: "running process keeps ties",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3, Priority: 1},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2, Priority: 1},
+				{ProcessID: 3, ArrivalTime: 1, BurstDuration: 2, Priority: 1},
+			},
+			want: []TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 3, Stop: 5}, {PID: 3, Start: 5, Stop: 7}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			res := SJFPrioritySchedule(context.Background(), io.Discard, "Priority", tt.processes, SchedulerOptions{})
+			if !reflect.DeepEqual(res.Gantt, tt.want) {
+				t.Errorf("Gantt = %v, want %v", res.Gantt, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchedulersIdleGap(t *testing.T) {
+	t.Parallel()
+	// nothing arrives before t=2, and P2 only at t=8 after P1 exited at t=5
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 2, BurstDuration: 3, Priority: 1},
+		{ProcessID: 2, ArrivalTime: 8, BurstDuration: 2, Priority: 2},
+	}
+	want := []TimeSlice{
+		{PID: IdlePID, Start: 0, Stop: 2},
+		{PID: 1, Start: 2, Stop: 5},
+		{PID: IdlePID, Start: 5, Stop: 8},
+		{PID: 2, Start: 8, Stop: 10},
+	}
+	for _, sched := range testSchedulers {
+		sched := sched
+		t.Run(sched.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			res := sched.schedule(context.Background(), &w, sched.name, processes, SchedulerOptions{})
+			if got := withIdle(res.Gantt); !reflect.DeepEqual(got, want) {
+				t.Errorf("Gantt with idle = %v, want %v", got, want)
+			}
+			if res.AvgWait != 0 {
+				t.Errorf("AvgWait = %v, want 0: idle time is nobody's wait", res.AvgWait)
+			}
+			if res.Utilization != 0.5 {
+				t.Errorf("Utilization = %v, want 5 busy ticks out of 10 = 0.5", res.Utilization)
+			}
+			if res.ContextSwitches != 1 {
+				t.Errorf("ContextSwitches = %d, want 1 from P1 to P2 across the idle gap", res.ContextSwitches)
+			}
+			if !strings.Contains(w.String(), "| idle  |   1   | idle  |   2   |") {
+				t.Errorf("Gantt chart does not show the idle gaps:\n%s", w.String())
+			}
+		})
+	}
+}
+
+func TestSchedulersThroughput(t *testing.T) {
+	t.Parallel()
+	// a single process arriving late: every scheduler runs it over [3, 7), so all of them complete
+	// one process by t=7
+	processes := []Process{{ProcessID: 1, ArrivalTime: 3, BurstDuration: 4, Priority: 1}}
+	want := FCFSSchedule(context.Background(), io.Discard, "FCFS", processes, SchedulerOptions{}).Throughput
+	if want != 1.0/7 {
+		t.Fatalf("FCFS Throughput = %v, want 1/7", want)
+	}
+	for _, sched := range testSchedulers {
+		sched := sched
+		t.Run(sched.name, func(t *testing.T) {
+			t.Parallel()
+			if got := sched.schedule(context.Background(), io.Discard, sched.name, processes, SchedulerOptions{}).Throughput; got != want {
+				t.Errorf("Throughput = %v, want FCFS's %v", got, want)
+			}
+		})
+	}
+}
+
+func TestSchedulersInterrupted(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+	}
+	for _, tt := range testSchedulers {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			var w bytes.Buffer
+			res := tt.schedule(ctx, &w, tt.name, processes, SchedulerOptions{})
+			if !errors.Is(res.Err, context.Canceled) {
+				t.Errorf("%s: Err = %v, want %v", tt.name, res.Err, context.Canceled)
+			}
+			if !strings.Contains(w.String(), "Interrupted at t=0") {
+				t.Errorf("%s: output is missing the interrupted note:\n%s", tt.name, w.String())
+			}
+		})
+	}
+}
+
+// TestSchedulersQuiet is not parallel: it swaps os.Stdout to catch schedulers printing around w.
+func TestSchedulersQuiet(t *testing.T) {
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 1, Priority: 3},
+	}
+	r, pw, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = pw
+	for _, tt := range testSchedulers {
+		tt.schedule(context.Background(), io.Discard, tt.name, processes, SchedulerOptions{})
+	}
+	os.Stdout = stdout
+	_ = pw.Close()
+
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out) > 0 {
+		t.Errorf("schedulers wrote to stdout:\n%s", out)
+	}
+}
+
+func TestSchedulersTimedOut(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+	}
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now())
+	defer cancel()
+
+	var w bytes.Buffer
+	res := RRSchedule(ctx, &w, "Round-robin", processes, SchedulerOptions{})
+	if !errors.Is(res.Err, context.DeadlineExceeded) {
+		t.Errorf("Err = %v, want %v", res.Err, context.DeadlineExceeded)
+	}
+	if want := "Timed out at t=0 with 0 processes completed"; !strings.Contains(w.String(), want) {
+		t.Errorf("output is missing %q:\n%s", want, w.String())
+	}
+}
+
+func TestRRScheduleIdle(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		wantGantt []TimeSlice
+		wantIdle  int64
+	}{
+		{
+			name: "gap between arrivals",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3},
+				{ProcessID: 2, ArrivalTime: 6, BurstDuration: 2},
+			},
+			wantGantt: []TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 6, Stop: 8}},
+			wantIdle:  3,
+		},
+		{
+			name: "first arrival after t=0",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 2, BurstDuration: 1},
+			},
+			wantGantt: []TimeSlice{{PID: 1, Start: 2, Stop: 3}},
+			wantIdle:  2,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			res := RRSchedule(context.Background(), &w, "Round-robin", tt.processes, SchedulerOptions{})
+			if res.Completed() != len(tt.processes) {
+				t.Fatalf("%d of %d processes completed", res.Completed(), len(tt.processes))
+			}
+			if !reflect.DeepEqual(res.Gantt, tt.wantGantt) {
+				t.Errorf("Gantt = %v, want %v", res.Gantt, tt.wantGantt)
+			}
+			if res.IdleTicks != tt.wantIdle {
+				t.Errorf("IdleTicks = %d, want %d", res.IdleTicks, tt.wantIdle)
+			}
+			if want := fmt.Sprintf("CPU idle: %d ticks", tt.wantIdle); !strings.Contains(w.String(), want) {
+				t.Errorf("output is missing %q:\n%s", want, w.String())
+			}
+		})
+	}
+}
+
+func TestRRScheduleQuantum(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 2},
+	}
+	// a quantum of 1 time-shares tick by tick
+	res := RRSchedule(context.Background(), io.Discard, "Round-robin", processes, SchedulerOptions{Quantum: 1})
+	want := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 2}, {PID: 3, Start: 2, Stop: 3},
+		{PID: 1, Start: 3, Stop: 4}, {PID: 2, Start: 4, Stop: 5}, {PID: 3, Start: 5, Stop: 6},
+		{PID: 1, Start: 6, Stop: 7},
+	}
+	if !reflect.DeepEqual(res.Gantt, want) {
+		t.Errorf("quantum 1 Gantt = %v, want %v", res.Gantt, want)
+	}
+
+	// a quantum longer than any burst runs each process to completion, like FCFS
+	res = RRSchedule(context.Background(), io.Discard, "Round-robin", processes, SchedulerOptions{Quantum: 1000})
+	fcfs := FCFSSchedule(context.Background(), io.Discard, "First-come, first-serve", processes, SchedulerOptions{})
+	if !reflect.DeepEqual(res.Gantt, fcfs.Gantt) || res.AvgWait != fcfs.AvgWait {
+		t.Errorf("quantum 1000 Gantt = %v with average wait %v, want FCFS's %v with %v", res.Gantt, res.AvgWait, fcfs.Gantt, fcfs.AvgWait)
+	}
+
+	// a negative quantum is rejected rather than run as a slice that never expires
+	var w bytes.Buffer
+	res = RRSchedule(context.Background(), &w, "Round-robin", processes, SchedulerOptions{Quantum: -1})
+	if !errors.Is(res.Err, ErrInvalidArgs) || res.Gantt != nil || w.Len() != 0 {
+		t.Errorf("quantum -1 Err = %v with Gantt %v and output %q, want only ErrInvalidArgs", res.Err, res.Gantt, w.String())
+	}
+}
+
+func TestRRScheduleOverhead(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 3},
+	}
+	// the dispatcher takes the first tick of every 2-tick quantum, so each does one tick of work
+	var w bytes.Buffer
+	res := RRSchedule(context.Background(), &w, "Round-robin", processes, SchedulerOptions{RROverhead: 0.5})
+	if res.StoppedAt != 16 {
+		t.Errorf("StoppedAt = %d, want %d", res.StoppedAt, 16)
+	}
+	if res.OverheadTicks != 8 {
+		t.Errorf("OverheadTicks = %d, want %d", res.OverheadTicks, 8)
+	}
+	for i, proc := range res.Data {
+		if got, want := proc.TAround, proc.ExitTime-processes[i].ArrivalTime; got != want {
+			t.Errorf("P%d TAround = %d, want exit - arrival = %d", processes[i].ProcessID, got, want)
+		}
+	}
+	if want := "Dispatcher overhead: 8 ticks, effective utilization 50.0%"; !strings.Contains(w.String(), want) {
+		t.Errorf("output is missing %q:\n%s", want, w.String())
+	}
+
+	// a tenth of a 2-tick quantum adds up to one overhead tick every five quanta
+	res = RRSchedule(context.Background(), io.Discard, "Round-robin", processes, SchedulerOptions{RROverhead: 0.1})
+	if res.OverheadTicks != 1 || res.StoppedAt != 9 {
+		t.Errorf("OverheadTicks = %d and StoppedAt = %d, want 1 and 9", res.OverheadTicks, res.StoppedAt)
+	}
+}
+
+func TestSchedulersHorizon(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+	}
+	const horizon = 7
+	for _, tt := range testSchedulers {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			res := tt.schedule(context.Background(), &w, tt.name, processes, SchedulerOptions{Horizon: horizon})
+			if !errors.Is(res.Err, ErrHorizon) {
+				t.Fatalf("Err = %v, want %v", res.Err, ErrHorizon)
+			}
+			if res.StoppedAt != horizon {
+				t.Errorf("StoppedAt = %d, want %d", res.StoppedAt, horizon)
+			}
+			if res.Completed() == len(processes) {
+				t.Errorf("every process completed within the horizon")
+			}
+
+			worked := make(map[int64]int64)
+			for _, slice := range res.Gantt {
+				if slice.Stop > horizon {
+					t.Errorf("slice %v runs past the horizon", slice)
+				}
+				worked[slice.PID] += slice.Stop - slice.Start
+			}
+			for i, proc := range res.Data {
+				p := processes[i]
+				if proc.ExitTime > horizon {
+					t.Errorf("P%d ExitTime = %d, past the horizon", p.ProcessID, proc.ExitTime)
+				}
+				if proc.ExitTime == 0 && worked[p.ProcessID]+proc.Remaining != p.BurstDuration {
+					t.Errorf("P%d ran %d with %d remaining, want a total of %d", p.ProcessID, worked[p.ProcessID], proc.Remaining, p.BurstDuration)
+				}
+				if proc.ExitTime != 0 {
+					continue
+				}
+				// an unfinished process has no turnaround or exit to show yet
+				if proc.TAround != 0 {
+					t.Errorf("P%d TAround = %d, want it unset while unfinished", p.ProcessID, proc.TAround)
+				}
+				for col, name := range res.Header {
+					switch name {
+					case "Turnaround", "Norm.TA", "Exit":
+						if cell := res.Rows[i][col]; cell != "-" {
+							t.Errorf("P%d %s = %q, want \"-\" while unfinished", p.ProcessID, name, cell)
+						}
+					}
+				}
+			}
+			if !strings.Contains(w.String(), "Horizon t=7 reached") {
+				t.Errorf("output is missing the horizon note:\n%s", w.String())
+			}
+		})
+	}
+}
+
+func TestSchedulersMaxTicks(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+	}
+	for _, tt := range testSchedulers {
+		tt := tt
+		if tt.name == "FCFS" {
+			continue // computed per process rather than tick by tick, so it always terminates
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			res := tt.schedule(context.Background(), &w, tt.name, processes, SchedulerOptions{MaxTicks: 6})
+			if !errors.Is(res.Err, ErrTickLimit) {
+				t.Fatalf("Err = %v, want %v", res.Err, ErrTickLimit)
+			}
+			if want := "simulation exceeded 6 ticks, possible non-terminating schedule"; !strings.Contains(w.String(), want) {
+				t.Errorf("output is missing %q:\n%s", want, w.String())
+			}
+
+			// a schedule exactly as long as the limit still finishes
+			if res := tt.schedule(context.Background(), io.Discard, tt.name, processes, SchedulerOptions{MaxTicks: 14}); res.Err != nil {
+				t.Errorf("Err with a limit of 14 = %v, want nil", res.Err)
+			}
+		})
+	}
+}
+
+func TestSchedulersExcludeNeverRun(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 1},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 4, Priority: 2},
+		{ProcessID: 3, ArrivalTime: 20, BurstDuration: 3, Priority: 1},
+	}
+	for _, tt := range testSchedulers {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			res := tt.schedule(context.Background(), &w, tt.name, processes, SchedulerOptions{Horizon: 8, ExcludeNeverRun: true})
+			if res.Data[2].FirstRun >= 0 {
+				t.Fatalf("P3 ran at t=%d, before arriving", res.Data[2].FirstRun)
+			}
+			if strings.Contains(w.String(), "|  3 |") {
+				t.Errorf("the table lists P3, which never ran:\n%s", w.String())
+			}
+			if !strings.Contains(w.String(), "Excluded 1 processes that never ran: P3") {
+				t.Errorf("output is missing the excluded processes:\n%s", w.String())
+			}
+		})
+	}
+}
+
+// orderIndependentSchedulers lists the schedulers whose selection depends only on process
+// attributes, with ties broken by arrival then PID, so the input row order must not matter.
+var orderIndependentSchedulers = []struct {
+	name     string
+	schedule func(context.Context, io.Writer, string, []Process, SchedulerOptions) ScheduleResult
+}{
+	{name: "FCFS", schedule: FCFSSchedule},
+	{name: "SJF", schedule: NonPreemptiveSJFSchedule},
+	{name: "HRRN", schedule: HRRNSchedule},
+	{name: "SRTF", schedule: SRTFSchedule},
+	{name: "SJF priority", schedule: SJFPrioritySchedule},
+	{name: "Priority", schedule: PrioritySchedule},
+	{name: "Arrival priority", schedule: ArrivalPreemptiveSchedule},
+	{name: "EDF", schedule: EDFSchedule},
+}
+
+// permutations returns every ordering of processes.
+func permutations(processes []Process) [][]Process {
+	if len(processes) <= 1 {
+		return [][]Process{append([]Process(nil), processes...)}
+	}
+	var perms [][]Process
+	for i := range processes {
+		rest := make([]Process, 0, len(processes)-1)
+		rest = append(rest, processes[:i]...)
+		rest = append(rest, processes[i+1:]...)
+		for _, perm := range permutations(rest) {
+			perms = append(perms, append([]Process{processes[i]}, perm...))
+		}
+	}
+	return perms
+}
+
+func TestSchedulersRowOrderIndependent(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 3, Priority: 2},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 3, Priority: 1},
+		{ProcessID: 4, ArrivalTime: 4, BurstDuration: 1, Priority: 3},
+	}
+	// byPID keys the per-process data by process ID, since Data follows the input row order
+	byPID := func(rows []Process, res ScheduleResult) map[int64]ProcessData {
+		data := make(map[int64]ProcessData, len(rows))
+		for i, p := range rows {
+			data[p.ProcessID] = res.Data[i]
+		}
+		return data
+	}
+	for _, sched := range orderIndependentSchedulers {
+		sched := sched
+		t.Run(sched.name, func(t *testing.T) {
+			t.Parallel()
+			want := sched.schedule(context.Background(), io.Discard, sched.name, processes, SchedulerOptions{})
+			wantData := byPID(processes, want)
+			for _, perm := range permutations(processes) {
+				got := sched.schedule(context.Background(), io.Discard, sched.name, perm, SchedulerOptions{})
+				order := make([]int64, len(perm))
+				for i, p := range perm {
+					order[i] = p.ProcessID
+				}
+				if !reflect.DeepEqual(got.Gantt, want.Gantt) {
+					t.Errorf("rows %v: Gantt = %v, want %v", order, got.Gantt, want.Gantt)
+				}
+				if gotData := byPID(perm, got); !reflect.DeepEqual(gotData, wantData) {
+					t.Errorf("rows %v: process data = %v, want %v", order, gotData, wantData)
+				}
+				if got.AvgWait != want.AvgWait || got.AvgTurnaround != want.AvgTurnaround || got.Throughput != want.Throughput {
+					t.Errorf("rows %v: averages = %.2f/%.2f/%.2f, want %.2f/%.2f/%.2f", order,
+						got.AvgWait, got.AvgTurnaround, got.Throughput, want.AvgWait, want.AvgTurnaround, want.Throughput)
+				}
+			}
+		})
+	}
+}
+
+func TestSchedulersIdenticalProcesses(t *testing.T) {
+	t.Parallel()
+	// the processes only differ in PID, which must then decide every tie
+	processes := []Process{
+		{ProcessID: 7, ArrivalTime: 1, BurstDuration: 2, Priority: 3},
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 2, Priority: 3},
+		{ProcessID: 5, ArrivalTime: 1, BurstDuration: 2, Priority: 3},
+	}
+	for _, sched := range testSchedulers {
+		sched := sched
+		t.Run(sched.name, func(t *testing.T) {
+			t.Parallel()
+			res := sched.schedule(context.Background(), io.Discard, sched.name, processes, SchedulerOptions{})
+			seen := make(map[string]int, len(processes))
+			for _, row := range res.Rows {
+				seen[row[0]]++
+			}
+			for _, p := range processes {
+				if n := seen[fmt.Sprint(p.ProcessID)]; n != 1 {
+					t.Errorf("P%d appears %d times in the table, want once", p.ProcessID, n)
+				}
+			}
+			if again := sched.schedule(context.Background(), io.Discard, sched.name, processes, SchedulerOptions{}); !reflect.DeepEqual(again, res) {
+				t.Errorf("rerun = %+v, want %+v", again, res)
+			}
+		})
+	}
+
+	want := []TimeSlice{{PID: 3, Start: 1, Stop: 3}, {PID: 5, Start: 3, Stop: 5}, {PID: 7, Start: 5, Stop: 7}}
+	for _, sched := range orderIndependentSchedulers {
+		sched := sched
+		t.Run(sched.name+"/Gantt", func(t *testing.T) {
+			t.Parallel()
+			res := sched.schedule(context.Background(), io.Discard, sched.name, processes, SchedulerOptions{})
+			if !reflect.DeepEqual(res.Gantt, want) {
+				t.Errorf("Gantt = %v, want %v", res.Gantt, want)
+			}
+		})
+	}
+}
+
+func TestSchedulersNonPreemptible(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4, Priority: 2, NonPreemptible: true},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 5, BurstDuration: 3, Priority: 3},
+		{ProcessID: 4, ArrivalTime: 6, BurstDuration: 1, Priority: 1},
+	}
+	// P1 keeps the CPU from P2, while P3 is still preempted by P4
+	wantGantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 4},
+		{PID: 2, Start: 4, Stop: 5},
+		{PID: 3, Start: 5, Stop: 6},
+		{PID: 4, Start: 6, Stop: 7},
+		{PID: 3, Start: 7, Stop: 9},
+	}
+	wantNotes := []string{"t=1 P1 kept the CPU from P2: non-preemptible"}
+	for _, sched := range []struct {
+		name     string
+		schedule func(context.Context, io.Writer, string, []Process, SchedulerOptions) ScheduleResult
+	}{
+		{name: "SRTF", schedule: SRTFSchedule},
+		{name: "Priority", schedule: PrioritySchedule},
+	} {
+		sched := sched
+		t.Run(sched.name, func(t *testing.T) {
+			t.Parallel()
+			res := sched.schedule(context.Background(), io.Discard, sched.name, processes, SchedulerOptions{})
+			if !reflect.DeepEqual(res.Gantt, wantGantt) {
+				t.Errorf("Gantt = %v, want %v", res.Gantt, wantGantt)
+			}
+			if !reflect.DeepEqual(res.Notes, wantNotes) {
+				t.Errorf("Notes = %q, want %q", res.Notes, wantNotes)
+			}
+		})
+	}
+}
+
+func Test_loadProcesses(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		r io.Reader
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []Process
+		wantErr error
+	}{
+		{
+			name: "bad CSV",
+			args: args{
+				r: iotest.ErrReader(io.ErrUnexpectedEOF),
+			},
+			wantErr: io.ErrUnexpectedEOF,
+		},
+		{
+			name: "success",
+			args: args{
+				r: strings.NewReader(`1,5,0,2
+2,9,3,1
+3,6,3,3`),
+			},
+			want: []Process{
+				{
+					ProcessID:     1,
+					ArrivalTime:   0,
+					BurstDuration: 5,
+					Weight:        1,
+					Priority:      2,
+				},
+				{
+					ProcessID:     2,
+					ArrivalTime:   3,
+					BurstDuration: 9,
+					Weight:        1,
+					Priority:      1,
+				},
+				{
+					ProcessID:     3,
+					ArrivalTime:   3,
+					BurstDuration: 6,
+					Weight:        1,
+					Priority:      3,
+				},
+			},
+		},
+		{
+			name: "empty priority cells",
+			args: args{
+				r: strings.NewReader(`1,5,0,
+2,9,3,1
+3,6,3, `),
+			},
+			want: []Process{
+				{
+					ProcessID:     1,
+					ArrivalTime:   0,
+					BurstDuration: 5,
+					Weight:        1,
+				},
+				{
+					ProcessID:     2,
+					ArrivalTime:   3,
+					BurstDuration: 9,
+					Weight:        1,
+					Priority:      1,
+				},
+				{
+					ProcessID:     3,
+					ArrivalTime:   3,
+					BurstDuration: 6,
+					Weight:        1,
+				},
+			},
+		},
+		{
+			name: "mixed integer and fractional priorities",
+			args: args{
+				r: strings.NewReader(`1,5,0,2
+2,9,3,1.25
+3,6,3,.5`),
+			},
+			want: []Process{
+				{
+					ProcessID:     1,
+					ArrivalTime:   0,
+					BurstDuration: 5,
+					Weight:        1,
+					Priority:      2,
+				},
+				{
+					ProcessID:     2,
+					ArrivalTime:   3,
+					BurstDuration: 9,
+					Weight:        1,
+					Priority:      1.25,
+				},
+				{
+					ProcessID:     3,
+					ArrivalTime:   3,
+					BurstDuration: 6,
+					Weight:        1,
+					Priority:      0.5,
+				},
+			},
+		},
+		{
+			name: "weight column",
+			args: args{
+				r: strings.NewReader(`1,5,0,2,0,3
+2,9,3,1,,`),
+			},
+			want: []Process{
+				{
+					ProcessID:     1,
+					ArrivalTime:   0,
+					BurstDuration: 5,
+					Weight:        3,
+					Priority:      2,
+				},
+				{
+					ProcessID:     2,
+					ArrivalTime:   3,
+					BurstDuration: 9,
+					Weight:        1,
+					Priority:      1,
+				},
+			},
+		},
+		{
+			name: "deadline column",
+			args: args{
+				r: strings.NewReader(`1,5,0,2,,,9
+2,9,3,1,,,`),
+			},
+			want: []Process{
+				{
+					ProcessID:     1,
+					ArrivalTime:   0,
+					BurstDuration: 5,
+					Weight:        1,
+					Priority:      2,
+					Deadline:      9,
+				},
+				{
+					ProcessID:     2,
+					ArrivalTime:   3,
+					BurstDuration: 9,
+					Weight:        1,
+					Priority:      1,
+				},
+			},
+		},
+		{
+			name: "release jitter",
+			args: args{
+				r: strings.NewReader(`1,5,0,2,0
+2,9,3,1,4`),
+			},
+			want: []Process{
+				{
+					ProcessID:     1,
+					ArrivalTime:   0,
+					BurstDuration: 5,
+					Weight:        1,
+					Priority:      2,
+				},
+				{
+					ProcessID:     2,
+					ArrivalTime:   3,
+					BurstDuration: 9,
+					Weight:        1,
+					Priority:      1,
+					ReleaseJitter: 4,
+				},
+			},
+		},
+		{
+			name: "pid and burst only",
+			args: args{
+				r: strings.NewReader(`1,5
+2,9`),
+			},
+			want: []Process{
+				{ProcessID: 1, BurstDuration: 5, Weight: 1},
+				{ProcessID: 2, BurstDuration: 9, Weight: 1},
+			},
+		},
+		{
+			name: "no priority column",
+			args: args{
+				r: strings.NewReader(`1,5,0
+2,9,3`),
+			},
+			want: []Process{
+				{ProcessID: 1, BurstDuration: 5, Weight: 1},
+				{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Weight: 1},
+			},
+		},
+		{
+			name: "mixed column counts",
+			args: args{
+				r: strings.NewReader(`1,5,0,2
+2,9
+3,6,3`),
+			},
+			want: []Process{
+				{ProcessID: 1, BurstDuration: 5, Priority: 2, Weight: 1},
+				{ProcessID: 2, BurstDuration: 9, Weight: 1},
+				{ProcessID: 3, ArrivalTime: 3, BurstDuration: 6, Weight: 1},
+			},
+		},
+		{
+			name: "header row",
+			args: args{
+				r: strings.NewReader(`ProcessID,BurstDuration,ArrivalTime,Priority
+1,5,0,2
+2,9,3,1`),
+			},
+			want: []Process{
+				{ProcessID: 1, BurstDuration: 5, Priority: 2, Weight: 1},
+				{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1, Weight: 1},
+			},
+		},
+		{
+			name: "header row only",
+			args: args{
+				r: strings.NewReader(`ProcessID, BurstDuration`),
+			},
+			want: []Process{},
+		},
+		{
+			name: "missing burst",
+			args: args{
+				r: strings.NewReader(`1,5
+2`),
+			},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name: "too many columns",
+			args: args{
+				r: strings.NewReader(`1,5,0,2,0,1,9
+2,9,3,1,0,1,9,4`),
+			},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name: "non-integer burst",
+			args: args{
+				r: strings.NewReader(`1,5
+2,abc`),
+			},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name: "infinite priority",
+			args: args{
+				r: strings.NewReader(`1,5,0,inf`),
+			},
+			wantErr: ErrInvalidArgs,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := loadProcesses(tt.args.r)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadProcesses() = %v, want %v", got, tt.want)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_loadProcessesComments(t *testing.T) {
+	t.Parallel()
+	processes, err := loadProcesses(strings.NewReader(`# two jobs
+1,5,0,2
+# the second arrives later
+2,3,4,1
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(processes) != 2 || processes[1].ProcessID != 2 {
+		t.Errorf("loadProcesses() = %v, want the two processes without the comments", processes)
+	}
+
+	// errors name the file's line despite the comments, and a quoted cell starting with # is data
+	_, err = loadProcesses(strings.NewReader("# header comment\n1,5\n\"# 2\",3\n"))
+	if want := `row 3, column 1: "# 2" is not an integer`; err == nil || !strings.HasSuffix(err.Error(), want) {
+		t.Errorf("loadProcesses() error = %v, want it to end in %q", err, want)
+	}
+}
+
+func Test_loadProcessesCellError(t *testing.T) {
+	t.Parallel()
+	_, err := loadProcesses(strings.NewReader(`1,5,0,2
+2,9,3,1
+3,abc,3,3`))
+	if want := `row 3, column 2: "abc" is not an integer`; err == nil || !strings.HasSuffix(err.Error(), want) {
+		t.Errorf("loadProcesses() error = %v, want it to end in %q", err, want)
+	}
+
+	_, err = loadProcesses(strings.NewReader("1,5\n2,9,3,1,0,1,9,9,4"))
+	if want := "row 2 has 9 columns, but a process has at most 8"; err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("loadProcesses() error = %v, want it to contain %q", err, want)
+	}
+}
+
+func Test_readProcessesRenumbering(t *testing.T) {
+	t.Parallel()
+	const input = "ID,Burst\n3,5\n7,2\n3,4\n7,1\n"
+	_, anomalies, err := readProcesses(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"row 1: header row skipped", "row 4: process ID 3 duplicates row 2", "row 5: process ID 7 duplicates row 3"}; !reflect.DeepEqual(anomalies, want) {
+		t.Errorf("readProcesses() anomalies = %q, want %q", anomalies, want)
+	}
+
+	processes, anomalies, err := readProcessesWith(strings.NewReader(input), loadOptions{renumber: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"row 1: header row skipped"}; !reflect.DeepEqual(anomalies, want) {
+		t.Errorf("anomalies = %q, want only %q once renumbered", anomalies, want)
+	}
+	var ids []int64
+	for _, p := range processes {
+		ids = append(ids, p.ProcessID)
+	}
+	if want := []int64{3, 7, 8, 9}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("IDs = %v, want %v: the repeats numbered on from the largest", ids, want)
+	}
+}
+
+func Test_responseTimes(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 8},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 4},
+	}
+	pd := []ProcessData{{FirstRun: 0}, {FirstRun: 3}, {FirstRun: -1}}
+	avg, weighted := responseTimes(processes, pd)
+	if avg != 1 {
+		t.Errorf("average response = %v, want 1", avg)
+	}
+	if weighted != 1.6 { // (2*0 + 8*2) / (2+8)
+		t.Errorf("weighted response = %v, want 1.6", weighted)
+	}
+}
+
+func Test_validateProcesses(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		wantErrs  []string
+	}{
+		{
+			name:      "valid",
+			processes: []Process{{ProcessID: 1, BurstDuration: 5}, {ProcessID: 2, BurstDuration: 1, ArrivalTime: 3}},
+		},
+		{
+			name: "invalid",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 0},
+				{ProcessID: 2, BurstDuration: 1, ArrivalTime: -1},
+				{ProcessID: 1, BurstDuration: 1},
+				{ProcessID: 3, BurstDuration: 1, Weight: -2},
+			},
+			wantErrs: []string{
+				"process 1: burst duration must be positive, got 0",
+				"process 2: arrival time must not be negative, got -1",
+				"process 1: duplicate process ID",
+				"process 3: weight must not be negative, got -2",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateProcesses(tt.processes)
+			if (err != nil) != (len(tt.wantErrs) > 0) {
+				t.Fatalf("validateProcesses() error = %v, want %v", err, tt.wantErrs)
+			}
+			if err != nil && err.Error() != strings.Join(tt.wantErrs, "\n") {
+				t.Errorf("validateProcesses() error = %q, want %q", err, strings.Join(tt.wantErrs, "\n"))
+			}
+		})
+	}
+}
+
+func Test_checkSchedulable(t *testing.T) {
+	t.Parallel()
+	// a duplicate ID is only an anomaly, so it doesn't stop the schedulers
+	if err := checkSchedulable([]Process{{ProcessID: 1, BurstDuration: 2}, {ProcessID: 1, BurstDuration: 3}}); err != nil {
+		t.Errorf("checkSchedulable() with a duplicate ID error = %v, want nil", err)
+	}
+	err := checkSchedulable([]Process{{ProcessID: 1, BurstDuration: -1}, {ProcessID: 2, BurstDuration: 1, ArrivalTime: -3}})
+	want := "invalid args: process 1: burst duration must be positive, got -1\nprocess 2: arrival time must not be negative, got -3"
+	if !errors.Is(err, ErrInvalidArgs) || err.Error() != want {
+		t.Errorf("checkSchedulable() error = %q, want %q", err, want)
+	}
+}
+
+func Test_applyBacklog(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 3, ArrivalTime: 4, ReleaseJitter: 2},
+		{ProcessID: 2, BurstDuration: 2, ArrivalTime: 6},
+		{ProcessID: 3, BurstDuration: 1, ArrivalTime: 9},
+	}
+	if err := applyBacklog(processes, 2); err != nil {
+		t.Fatal(err)
+	}
+	want := []Process{
+		{ProcessID: 1, BurstDuration: 3},
+		{ProcessID: 2, BurstDuration: 2},
+		{ProcessID: 3, BurstDuration: 1, ArrivalTime: 9},
+	}
+	if !reflect.DeepEqual(processes, want) {
+		t.Errorf("applyBacklog() = %v, want %v", processes, want)
+	}
+	res := PrioritySchedule(context.Background(), io.Discard, "backlog", processes, SchedulerOptions{Backlog: 2})
+	if res.Data[1].TotalWait != 3 {
+		t.Errorf("backlogged P2 wait = %d, want 3", res.Data[1].TotalWait)
+	}
+
+	for _, n := range []int{-1, 4} {
+		if err := applyBacklog(processes, n); !errors.Is(err, ErrInvalidArgs) {
+			t.Errorf("applyBacklog(%d) error = %v, want %v", n, err, ErrInvalidArgs)
+		}
+	}
+}
+
+func loadFixture(t *testing.T, p ...string) string {
+	b, err := os.ReadFile(path.Join(p...))
+	if err != nil {
+		t.Fail()
+	}
+
+	// fixtures are checked out with CRLF line endings
+	return strings.ReplaceAll(string(b), "\r\n", "\n")
+}
+
+func Test_openProcessingFile1(t *testing.T) {
+	tmpFile, tErr := os.CreateTemp(t.TempDir(), "")
+	if tErr != nil {
+		t.Fatal(tErr)
+	}
+
+	type args struct {
+		args []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *os.File
+		wantErr bool
+	}{
+		{
+			name: "success",
+			args: args{
+				args: []string{"binary_name", tmpFile.Name()},
+			},
+			want: tmpFile,
+		},
+		{
+			name: "stdin",
+			args: args{
+				args: []string{"binary_name"},
+			},
+			want: os.Stdin,
+		},
+		{
+			name: "too many args",
+			args: args{
+				args: []string{"binary_name", tmpFile.Name(), tmpFile.Name()},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bad file",
+			args: args{
+				args: []string{"binary_name", "bad_file_name"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, closeFn, err := openProcessingFile(tt.args.args...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("openProcessingFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if got == nil {
+				t.Fatal("file is unexpectedly nil")
+			}
+			if closeFn == nil {
+				t.Fatal("closeFn is unexpectedly nil")
+			}
+			t.Cleanup(closeFn)
+			if tt.want == os.Stdin {
+				if got != os.Stdin {
+					t.Fatalf("openProcessingFile() = %v, want stdin", got.Name())
+				}
+				return
+			}
+
+			f1, err := os.Stat(got.Name())
+			if err != nil {
+				t.Fatalf("Could not stat file: %v", got)
+			}
+			f2, err := os.Stat(tt.want.Name())
+			if err != nil {
+				t.Fatalf("Could not stat file: %v", tt.want)
+			}
+
+			if !os.SameFile(f1, f2) {
+				t.Fatal("files are not the same")
+			}
+		})
+	}
+}
+
+func Test_preemptionPenalty(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		work     int64
+		fraction float64
+		want     int64
+	}{
+		{name: "no penalty", work: 4, fraction: 0, want: 0},
+		{name: "full restart", work: 4, fraction: 1, want: 4},
+		{name: "half rounds to nearest", work: 3, fraction: 0.5, want: 2},
+		{name: "no work done", work: 0, fraction: 0.5, want: 0},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := preemptionPenalty(tt.work, tt.fraction); got != tt.want {
+				t.Errorf("preemptionPenalty() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_eventLog(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		gantt []TimeSlice
+		pd    []ProcessData
+	}
+	tests := []struct {
+		name string
+		args args
+		want []string
+	}{
+		{
+			name: "run to completion",
+			args: args{
+				gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 5}, {PID: 2, Start: 5, Stop: 14}},
+				pd:    []ProcessData{{ExitTime: 5}, {ExitTime: 14}},
+			},
+			want: []string{
+				"t=0 P1 dispatched",
+				"t=5 P1 completed",
+				"t=5 P2 dispatched",
+				"t=14 P2 completed",
+			},
+		},
+		{
+			name: "preemption",
+			args: args{
+				gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 3, Stop: 5}, {PID: 1, Start: 5, Stop: 7}},
+				pd:    []ProcessData{{ExitTime: 7}, {ExitTime: 5}},
+			},
+			want: []string{
+				"t=0 P1 dispatched",
+				"t=3 P1 preempted by P2",
+				"t=5 P2 completed",
+				"t=5 P1 dispatched",
+				"t=7 P1 completed",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := eventLog(tt.args.gantt, tt.args.pd); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("eventLog() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_outputDOT(t *testing.T) {
+	t.Parallel()
+	res := ScheduleResult{
+		Title: "Round-robin",
+		Gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 5}},
+	}
+	want := `digraph "Round-robin" {
+	rankdir=LR;
+	node [shape=box];
+	s0 [label="P1\n[0, 2)"];
+	s1 [label="P2\n[2, 5)"];
+	s0 -> s1 [label="t=2"];
+}
+`
+	var w bytes.Buffer
+	outputDOT(&w, res)
+	if got := w.String(); got != want {
+		t.Errorf("outputDOT() = %v, want %v", got, want)
+	}
+}
+
+func Test_scheduleHeader(t *testing.T) {
+	t.Parallel()
+	p := Process{ProcessID: 1, ArrivalTime: 2, BurstDuration: 3, Priority: 4, ReleaseJitter: 1, Weight: 5, Deadline: 10}
+	proc := ProcessData{TotalWait: 6, ExitTime: 11, FirstRun: 8, Laxity: -1}
+	tests := []struct {
+		name       string
+		cols       tableColumns
+		wantHeader []string
+		wantRow    []string
+	}{
+		{
+			name:       "default",
+			wantHeader: []string{"ID", "Priority", "Burst", "Arrival", "Wait", "Response", "Turnaround", "Norm.TA", "Exit"},
+			wantRow:    []string{"1", "4", "3", "2", "6", "6", "9", "3.00", "11"},
+		},
+		{
+			name:       "release and weight",
+			cols:       tableColumns{release: true, weight: true},
+			wantHeader: []string{"ID", "Priority", "Weight", "Burst", "Arrival", "Release", "Wait", "Response", "Turnaround", "Norm.TA", "Exit"},
+			wantRow:    []string{"1", "4", "5", "3", "2", "3", "6", "6", "9", "3.00", "11"},
+		},
+		{
+			name:       "laxity",
+			cols:       tableColumns{laxity: true},
+			wantHeader: []string{"ID", "Priority", "Burst", "Arrival", "Wait", "Response", "Turnaround", "Norm.TA", "Exit", "Laxity"},
+			wantRow:    []string{"1", "4", "3", "2", "6", "6", "9", "3.00", "11", "-1 (unmeetable)"},
+		},
+		{
+			name:       "deadline",
+			cols:       tableColumns{deadline: true, laxity: true},
+			wantHeader: []string{"ID", "Priority", "Burst", "Arrival", "Wait", "Response", "Turnaround", "Norm.TA", "Exit", "Deadline", "Missed", "Laxity"},
+			wantRow:    []string{"1", "4", "3", "2", "6", "6", "9", "3.00", "11", "10", "true", "-1 (unmeetable)"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := scheduleHeader(tt.cols); !reflect.DeepEqual(got, tt.wantHeader) {
+				t.Errorf("scheduleHeader() = %v, want %v", got, tt.wantHeader)
+			}
+			if got := scheduleRow(p, proc, tt.cols, 9); !reflect.DeepEqual(got, tt.wantRow) {
+				t.Errorf("scheduleRow() = %v, want %v", got, tt.wantRow)
+			}
+		})
+	}
+	fractional := p
+	fractional.Priority = 2.125
+	if got := scheduleRow(fractional, proc, tableColumns{}, 9)[1]; got != "2.125" {
+		t.Errorf("scheduleRow() priority = %q, want %q", got, "2.125")
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// MakespanGap compares a schedule's length against what any single-CPU schedule could achieve.
+type MakespanGap struct {
+	// Makespan is when the last process exited and Busy how long the CPU ran processes.
+	Makespan int64
+	Busy     int64
+	// LowerBound is the first release plus every burst back to back, the makespan if the
+	// CPU never idled after the first process arrived.
+	LowerBound int64
+	// ForcedIdle is the idle time any work-conserving schedule spends waiting for releases
+	// after the first, so it can't be blamed on the algorithm.
+	ForcedIdle int64
+}
+
+// Gap is how much longer than the lower bound the schedule ran.
+func (g MakespanGap) Gap() int64 {
+	return g.Makespan - g.LowerBound
+}
+
+// makespanGap measures a schedule's makespan against the lower bound of its processes.
+func makespanGap(processes []Process, pd []ProcessData, gantt []TimeSlice) MakespanGap {
+	var g MakespanGap
+	if len(processes) == 0 {
+		return g
+	}
+	for _, proc := range pd {
+		if proc.ExitTime > g.Makespan {
+			g.Makespan = proc.ExitTime
+		}
+	}
+	for _, slice := range gantt {
+		g.Busy += slice.Stop - slice.Start
+	}
+
+	releases := make([]Process, len(processes))
+	copy(releases, processes)
+	sort.Slice(releases, func(i, j int) bool { return releaseTime(releases[i]) < releaseTime(releases[j]) })
+	clock := releaseTime(releases[0])
+	g.LowerBound = clock
+	for _, p := range releases {
+		if release := releaseTime(p); release > clock {
+			g.ForcedIdle += release - clock
+			clock = release
+		}
+		clock += p.BurstDuration
+		g.LowerBound += p.BurstDuration
+	}
+	return g
+}
+
+// String summarises the gap for the notes under a schedule table.
+func (g MakespanGap) String() string {
+	return fmt.Sprintf("Makespan: %d, busy %d, lower bound %d: gap %d, of which %d forced by arrival gaps",
+		g.Makespan, g.Busy, g.LowerBound, g.Gap(), g.ForcedIdle)
+}
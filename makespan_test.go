@@ -0,0 +1,43 @@
+package main
+
+import "testing"
+
+func Test_makespanGap(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 1, BurstDuration: 3},
+		{ProcessID: 2, ArrivalTime: 7, BurstDuration: 2},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 1},
+	}
+	tests := []struct {
+		name  string
+		pd    []ProcessData
+		gantt []TimeSlice
+		want  MakespanGap
+	}{
+		{
+			name:  "work conserving",
+			pd:    []ProcessData{{ExitTime: 4}, {ExitTime: 9}, {ExitTime: 5}},
+			gantt: []TimeSlice{{PID: 1, Start: 1, Stop: 4}, {PID: 3, Start: 4, Stop: 5}, {PID: 2, Start: 7, Stop: 9}},
+			want:  MakespanGap{Makespan: 9, Busy: 6, LowerBound: 7, ForcedIdle: 2},
+		},
+		{
+			name: "lost work",
+			pd:   []ProcessData{{ExitTime: 6, LostWork: 1}, {ExitTime: 10}, {ExitTime: 3}},
+			gantt: []TimeSlice{
+				{PID: 1, Start: 1, Stop: 2}, {PID: 3, Start: 2, Stop: 3}, {PID: 1, Start: 3, Stop: 6},
+				{PID: 2, Start: 8, Stop: 10},
+			},
+			want: MakespanGap{Makespan: 10, Busy: 7, LowerBound: 7, ForcedIdle: 2},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := makespanGap(processes, tt.pd, tt.gantt); got != tt.want {
+				t.Errorf("makespanGap() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// outputMermaid renders the Gantt chart as a fenced Mermaid gantt diagram for Markdown: a task
+// per time slice, named after its PID, on a numeric axis where a second is a time unit. Idle time
+// stays blank, and the notes follow as Mermaid comments.
+func outputMermaid(w io.Writer, res ScheduleResult) {
+	_, _ = fmt.Fprintln(w, "```mermaid")
+	_, _ = fmt.Fprintln(w, "gantt")
+	_, _ = fmt.Fprintf(w, "    title %s\n", res.Title)
+	_, _ = fmt.Fprintln(w, "    dateFormat X")
+	_, _ = fmt.Fprintf(w, "    axisFormat %%s\n")
+	_, _ = fmt.Fprintln(w, "    section CPU")
+	for _, slice := range res.Gantt {
+		if slice.Start == slice.Stop {
+			continue // nothing ran
+		}
+		_, _ = fmt.Fprintf(w, "    P%d : %d, %ds\n", slice.PID, slice.Start, slice.Stop-slice.Start)
+	}
+	for _, note := range res.Notes {
+		_, _ = fmt.Fprintf(w, "    %%%% %s\n", note)
+	}
+	_, _ = fmt.Fprintln(w, "```")
+	_, _ = fmt.Fprintln(w)
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_outputMermaid(t *testing.T) {
+	t.Parallel()
+	res := ScheduleResult{
+		Title: "Round-robin",
+		Gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 4, Stop: 9}, {PID: 1, Start: 9, Stop: 10}},
+		Notes: []string{"P2 met deadline 9 with 0 to spare"},
+	}
+	want := "```mermaid\n" +
+		"gantt\n" +
+		"    title Round-robin\n" +
+		"    dateFormat X\n" +
+		"    axisFormat %s\n" +
+		"    section CPU\n" +
+		"    P1 : 0, 2s\n" +
+		"    P2 : 4, 5s\n" +
+		"    P1 : 9, 1s\n" +
+		"    %% P2 met deadline 9 with 0 to spare\n" +
+		"```\n\n"
+	var w bytes.Buffer
+	outputMermaid(&w, res)
+	if got := w.String(); got != want {
+		t.Errorf("outputMermaid() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// AggregateMetrics are the schedule-wide figures of a simulation, without any per-process detail.
+type AggregateMetrics struct {
+	AvgWait       float64
+	AvgTurnaround float64
+	Throughput    float64
+	// Utilization is the fraction of the simulated time the CPU spent running processes, not
+	// counting round-robin dispatcher overhead.
+	Utilization     float64
+	ContextSwitches int
+	FairnessIndex   float64
+	Completed       int
+	// Err is the result's Err: the simulation stopped before every process exited.
+	Err error
+}
+
+// Metrics runs the algorithm with the given name (see algorithms) and returns only its aggregate
+// metrics. It skips building the schedule table and rendering, so tight loops such as the quantum
+// sweep allocate far less than with the full ScheduleResult.
+func Metrics(algo string, processes []Process, opts SchedulerOptions) (AggregateMetrics, error) {
+	return metrics(context.Background(), algo, processes, opts)
+}
+
+// metrics is Metrics stopping early if the context is cancelled.
+func metrics(ctx context.Context, algo string, processes []Process, opts SchedulerOptions) (AggregateMetrics, error) {
+	for _, a := range algorithms {
+		if a.name != algo {
+			continue
+		}
+		opts.aggregateOnly = true
+		res := a.schedule.Schedule(ctx, io.Discard, a.title, processes, opts)
+		m := AggregateMetrics{
+			AvgWait:         res.AvgWait,
+			AvgTurnaround:   res.AvgTurnaround,
+			Throughput:      res.Throughput,
+			Utilization:     res.Utilization,
+			ContextSwitches: res.ContextSwitches,
+			FairnessIndex:   res.FairnessIndex,
+			Completed:       res.Completed(),
+			Err:             res.Err,
+		}
+		return m, nil
+	}
+	return AggregateMetrics{}, fmt.Errorf("%w: unknown algorithm %q", ErrInvalidArgs, algo)
+}
+
+// turnaroundFairness is Jain's index (see jainIndex) of the turnarounds of the processes that
+// completed: how evenly the schedule spread the time processes spent in the system.
+func turnaroundFairness(pd []ProcessData) float64 {
+	var turnarounds []float64
+	for _, proc := range pd {
+		if proc.ExitTime != 0 {
+			turnarounds = append(turnarounds, float64(proc.TAround))
+		}
+	}
+	return jainIndex(turnarounds)
+}
+
+// avgNormTurnaround averages the normalized turnaround, turnaround over burst, of the processes
+// that completed. It is 1 when no process waited and grows as short jobs wait behind long ones.
+func avgNormTurnaround(processes []Process, pd []ProcessData) float64 {
+	var (
+		total     float64
+		completed int
+	)
+	for i, proc := range pd {
+		if proc.ExitTime == 0 || processes[i].BurstDuration <= 0 {
+			continue
+		}
+		total += float64(proc.TAround) / float64(processes[i].BurstDuration)
+		completed++
+	}
+	if completed == 0 {
+		return 0
+	}
+	return total / float64(completed)
+}
+
+// utilization is the fraction of the elapsed time the Gantt chart's slices ran a process, less the
+// dispatcher overhead they include. It is zero before any time has elapsed.
+func utilization(gantt []TimeSlice, overhead, elapsed int64) float64 {
+	if elapsed <= 0 {
+		return 0
+	}
+	busy := -overhead
+	for _, slice := range gantt {
+		busy += slice.Stop - slice.Start
+	}
+	return float64(busy) / float64(elapsed)
+}
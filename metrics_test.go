@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+// metricsProcesses is a workload large enough for the allocations of rendering to show.
+var metricsProcesses = GenerateProcesses(50, 1)
+
+func TestMetrics(t *testing.T) {
+	t.Parallel()
+	for _, algo := range algorithms {
+		algo := algo
+		t.Run(algo.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := Metrics(algo.name, metricsProcesses, SchedulerOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			res := algo.schedule.Schedule(context.Background(), io.Discard, algo.title, metricsProcesses, SchedulerOptions{})
+			want := AggregateMetrics{
+				AvgWait:       res.AvgWait,
+				AvgTurnaround: res.AvgTurnaround,
+				Throughput:    res.Throughput,
+				// the workload leaves no gap between arrivals for the CPU to idle in
+				Utilization:     1,
+				ContextSwitches: contextSwitches(res.Gantt),
+				FairnessIndex:   res.FairnessIndex,
+				Completed:       res.Completed(),
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Metrics() = %+v, want %+v", got, want)
+			}
+		})
+	}
+
+	if _, err := Metrics("lottery", metricsProcesses, SchedulerOptions{}); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("Metrics(unknown) error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
+
+func Test_turnaroundFairness(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		pd   []ProcessData
+		want float64
+	}{
+		{name: "equal turnarounds", pd: []ProcessData{{TAround: 4, ExitTime: 4}, {TAround: 4, ExitTime: 8}}, want: 1},
+		// (2 + 6)² / (2 × (4 + 36)) = 64 / 80
+		{name: "unequal turnarounds", pd: []ProcessData{{TAround: 2, ExitTime: 2}, {TAround: 6, ExitTime: 8}}, want: 0.8},
+		{name: "unfinished left out", pd: []ProcessData{{TAround: 3, ExitTime: 3}, {TAround: 9}}, want: 1},
+		{name: "none completed", pd: []ProcessData{{TAround: 3}}, want: 0},
+	}
+	for _, tt := range tests {
+		if got := turnaroundFairness(tt.pd); got != tt.want {
+			t.Errorf("turnaroundFairness() %s = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func Test_avgNormTurnaround(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: 1, BurstDuration: 4}, {ProcessID: 2, BurstDuration: 1}, {ProcessID: 3, BurstDuration: 2}}
+	// (4/4 + 5/1) / 2: the short job waiting behind the long one dominates, P3 never exited
+	pd := []ProcessData{{TAround: 4, ExitTime: 4}, {TAround: 5, ExitTime: 5}, {TAround: 7}}
+	if got := avgNormTurnaround(processes, pd); got != 3 {
+		t.Errorf("avgNormTurnaround() = %v, want 3", got)
+	}
+	if got := avgNormTurnaround(processes[2:], pd[2:]); got != 0 {
+		t.Errorf("avgNormTurnaround() with none completed = %v, want 0", got)
+	}
+}
+
+func BenchmarkMetrics(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = Metrics("rr", metricsProcesses, SchedulerOptions{})
+	}
+}
+
+// BenchmarkScheduleResult is the full result path Metrics narrows, for comparing allocations.
+func BenchmarkScheduleResult(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = RRSchedule(context.Background(), io.Discard, "Round-robin", metricsProcesses, SchedulerOptions{})
+	}
+}
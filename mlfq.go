@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// defaultMLFQQuanta are the quanta of MLFQSchedule's queues, from the top queue down, used when
+// none are configured.
+var defaultMLFQQuanta = []int64{2, 4, 8}
+
+func (o SchedulerOptions) mlfqQuanta() []int64 {
+	if len(o.MLFQQuanta) == 0 {
+		return defaultMLFQQuanta
+	}
+	return o.MLFQQuanta
+}
+
+// MLFQSchedule outputs a multilevel feedback queue schedule with a queue per quantum of
+// opts.MLFQQuanta, the top queue first. A released process enters the top queue, the process at
+// the head of the highest non-empty queue runs, and one that uses up its queue's quantum drops to
+// the tail of the next queue down; the bottom queue is round-robin with its own quantum. A process
+// released into a higher queue preempts the running one, which goes back to the tail of its queue
+// without dropping.
+//
+// The table gets a Queue column with the queue each process ended in, 1 being the top.
+func MLFQSchedule(ctx context.Context, w io.Writer, title string, processes []Process, opts SchedulerOptions) ScheduleResult {
+	var (
+		quanta = opts.mlfqQuanta()
+		queues = make([][]int, len(quanta))   // indices of the waiting processes, per queue
+		level  = make([]int, len(processes))  // queue of every process, by index
+		queued = make([]bool, len(processes)) // waiting in its queue
+		used   int64                          // ticks of its queue's quantum the current process has run
+	)
+	enqueue := func(i int) {
+		queues[level[i]] = append(queues[level[i]], i)
+		queued[i] = true
+	}
+
+	s := runTicks(ctx, title, processes, opts, tickPolicy{
+		next: func(s *tickSim) int {
+			current := s.current
+			for i := range processes {
+				if i != current && !queued[i] && s.ready(i) { // released, or back from I/O
+					enqueue(i)
+				}
+			}
+			top := -1 // the highest queue with a waiting process
+			for l := range queues {
+				if len(queues[l]) > 0 {
+					top = l
+					break
+				}
+			}
+
+			next := current
+			switch {
+			case current >= 0 && used == quanta[level[current]]: // used up the quantum
+				if level[current] < len(quanta)-1 {
+					level[current]++
+				}
+				enqueue(current)
+				next = -1
+			case current >= 0 && top >= 0 && top < level[current]: // preempted from a higher queue
+				enqueue(current)
+				next = -1
+			}
+			if next < 0 {
+				for l := range queues {
+					if len(queues[l]) > 0 {
+						next, queues[l] = queues[l][0], queues[l][1:]
+						queued[next] = false
+						break
+					}
+				}
+				used = 0
+			}
+			return next
+		},
+		ran: func(s *tickSim) { used++ },
+	})
+
+	res := s.result(title)
+	if res.Rows != nil {
+		res.Header = append(res.Header, "Queue")
+		for i := range res.Rows {
+			res.Rows[i] = append(res.Rows[i], fmt.Sprint(level[i]+1))
+		}
+	}
+	outputResult(w, opts, res)
+	return res
+}
+
+// parseMLFQQuanta parses the comma-separated quanta of MLFQSchedule's queues, from the top queue
+// down, e.g. "2,4,8".
+func parseMLFQQuanta(spec string) ([]int64, error) {
+	var quanta []int64
+	for _, field := range strings.Split(spec, ",") {
+		q, err := strconv.ParseInt(strings.TrimSpace(field), 10, 64)
+		if err != nil || q < 1 {
+			return nil, fmt.Errorf("%w: mlfq-quanta: %q is not a quantum of at least 1", ErrInvalidArgs, field)
+		}
+		quanta = append(quanta, q)
+	}
+	return quanta, nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestMLFQSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 10},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1},
+		{ProcessID: 3, ArrivalTime: 5, BurstDuration: 3},
+	}
+	// P1 drops to queue 2 after its first quantum of 2, P3's arrival at t=5 preempts it there, and
+	// it drops to queue 3 after running its next quantum of 4 in full
+	want := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 2},
+		{PID: 2, Start: 2, Stop: 3},
+		{PID: 1, Start: 3, Stop: 5},
+		{PID: 3, Start: 5, Stop: 7},
+		{PID: 1, Start: 7, Stop: 11},
+		{PID: 3, Start: 11, Stop: 12},
+		{PID: 1, Start: 12, Stop: 14},
+	}
+	res := MLFQSchedule(context.Background(), io.Discard, "Multilevel feedback queue", processes, SchedulerOptions{})
+	if !reflect.DeepEqual(res.Gantt, want) {
+		t.Errorf("Gantt = %v, want %v", res.Gantt, want)
+	}
+	if got := res.Header[len(res.Header)-1]; got != "Queue" {
+		t.Errorf("last column = %q, want %q", got, "Queue")
+	}
+	var queues []string
+	for _, row := range res.Rows {
+		queues = append(queues, row[len(row)-1])
+	}
+	if want := []string{"3", "1", "2"}; !reflect.DeepEqual(queues, want) {
+		t.Errorf("Queue column = %v, want %v", queues, want)
+	}
+
+	// with a single queue every process stays at the bottom, which is round-robin
+	res = MLFQSchedule(context.Background(), io.Discard, "Multilevel feedback queue", processes[:2], SchedulerOptions{MLFQQuanta: []int64{3}})
+	want = []TimeSlice{
+		{PID: 1, Start: 0, Stop: 3},
+		{PID: 2, Start: 3, Stop: 4},
+		{PID: 1, Start: 4, Stop: 11},
+	}
+	if !reflect.DeepEqual(res.Gantt, want) {
+		t.Errorf("single queue Gantt = %v, want %v", res.Gantt, want)
+	}
+}
+
+func Test_parseMLFQQuanta(t *testing.T) {
+	t.Parallel()
+	got, err := parseMLFQQuanta("2, 4,8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int64{2, 4, 8}; !reflect.DeepEqual(got, want) {
+		t.Errorf("parseMLFQQuanta() = %v, want %v", got, want)
+	}
+	for _, spec := range []string{"", "2,0", "2,x"} {
+		if _, err := parseMLFQQuanta(spec); !errors.Is(err, ErrInvalidArgs) {
+			t.Errorf("parseMLFQQuanta(%q) error = %v, want %v", spec, err, ErrInvalidArgs)
+		}
+	}
+}
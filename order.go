@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+// GanttFromOrder runs the processes non-preemptively in the given order of process IDs, like
+// FCFS, and returns the resulting timeline and metrics so a hand-built order can be compared
+// with the algorithms. The result's Data and Rows follow that order. Unless the order names
+// every process exactly once, the result only carries an ErrInvalidArgs error in Err.
+func GanttFromOrder(processes []Process, order []int64) ([]TimeSlice, ScheduleResult) {
+	byID := make(map[int64]Process, len(processes))
+	for _, p := range processes {
+		byID[p.ProcessID] = p
+	}
+	if len(order) != len(processes) || len(byID) != len(processes) {
+		return nil, ScheduleResult{Err: fmt.Errorf("%w: order of %d process IDs for %d processes with unique IDs",
+			ErrInvalidArgs, len(order), len(processes))}
+	}
+
+	ordered := make([]Process, 0, len(order))
+	for _, pid := range order {
+		p, ok := byID[pid]
+		if !ok {
+			return nil, ScheduleResult{Err: fmt.Errorf("%w: order repeats or names unknown process ID %d", ErrInvalidArgs, pid)}
+		}
+		delete(byID, pid)
+		ordered = append(ordered, p)
+	}
+
+	res := fcfsResult(context.Background(), "Custom order", ordered, SchedulerOptions{})
+	return res.Gantt, res
+}
+
+// maxOptimalSearch is the most processes IsOptimalNonPreemptive searches: it tries every order,
+// and 8! is 40320 orders.
+const maxOptimalSearch = 8
+
+// IsOptimalNonPreemptive reports whether running the processes non-preemptively in the given order
+// of process IDs (see GanttFromOrder) achieves the minimum average waiting time of any order. If
+// not, it also returns an order that does: the first in lexicographic order of the input positions.
+// An order that isn't a permutation of the process IDs is never optimal.
+//
+// The minimum is found by brute force over every order, so it only handles up to
+// maxOptimalSearch (8) processes; for more it reports false with a nil order.
+func IsOptimalNonPreemptive(processes []Process, order []int64) (bool, []int64) {
+	if len(processes) > maxOptimalSearch {
+		return false, nil
+	}
+	_, given := GanttFromOrder(processes, order)
+
+	var (
+		best      []int64
+		bestWait  float64
+		candidate = make([]int64, 0, len(processes))
+		used      = make([]bool, len(processes))
+		permute   func()
+	)
+	permute = func() {
+		if len(candidate) == len(processes) {
+			if _, res := GanttFromOrder(processes, candidate); res.Err == nil && (best == nil || res.AvgWait < bestWait) {
+				best, bestWait = append([]int64(nil), candidate...), res.AvgWait
+			}
+			return
+		}
+		for i := range processes {
+			if used[i] {
+				continue
+			}
+			used[i] = true
+			candidate = append(candidate, processes[i].ProcessID)
+			permute()
+			candidate = candidate[:len(candidate)-1]
+			used[i] = false
+		}
+	}
+	permute()
+
+	if given.Err == nil && given.AvgWait <= bestWait+1e-9 { // allow for rounding in the averages
+		return true, nil
+	}
+	return false, best
+}
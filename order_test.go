@@ -0,0 +1,75 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestGanttFromOrder(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 1, Priority: 3},
+	}
+	gantt, res := GanttFromOrder(processes, []int64{1, 3, 2})
+	if res.Err != nil {
+		t.Fatal(res.Err)
+	}
+	wantGantt := []TimeSlice{{PID: 1, Start: 0, Stop: 5}, {PID: 3, Start: 5, Stop: 6}, {PID: 2, Start: 6, Stop: 8}}
+	if !reflect.DeepEqual(gantt, wantGantt) {
+		t.Errorf("GanttFromOrder() gantt = %v, want %v", gantt, wantGantt)
+	}
+	// P3 waits 3 and P2 waits 5, in the order given
+	wantData := []ProcessData{
+		{TotalWait: 0, TAround: 5, ExitTime: 5, FirstRun: 0, State: StateExited},
+		{TotalWait: 3, TAround: 4, ExitTime: 6, FirstRun: 5, State: StateExited},
+		{TotalWait: 5, TAround: 7, ExitTime: 8, FirstRun: 6, State: StateExited},
+	}
+	if !reflect.DeepEqual(res.Data, wantData) {
+		t.Errorf("GanttFromOrder() data = %v, want %v", res.Data, wantData)
+	}
+	if res.AvgWait != 8.0/3 {
+		t.Errorf("GanttFromOrder() AvgWait = %v, want %v", res.AvgWait, 8.0/3)
+	}
+
+	for _, order := range [][]int64{{1, 2}, {1, 2, 2}, {1, 2, 4}} {
+		if _, res := GanttFromOrder(processes, order); !errors.Is(res.Err, ErrInvalidArgs) {
+			t.Errorf("GanttFromOrder(%v) Err = %v, want %v", order, res.Err, ErrInvalidArgs)
+		}
+	}
+}
+
+func TestIsOptimalNonPreemptive(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 6},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2},
+		{ProcessID: 3, ArrivalTime: 0, BurstDuration: 4},
+	}
+	tests := []struct {
+		name        string
+		order       []int64
+		wantOptimal bool
+		wantBetter  []int64
+	}{
+		{name: "shortest first", order: []int64{2, 3, 1}, wantOptimal: true},
+		{name: "input order", order: []int64{1, 2, 3}, wantBetter: []int64{2, 3, 1}},
+		{name: "not a permutation", order: []int64{2, 2, 1}, wantBetter: []int64{2, 3, 1}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			optimal, better := IsOptimalNonPreemptive(processes, tt.order)
+			if optimal != tt.wantOptimal || !reflect.DeepEqual(better, tt.wantBetter) {
+				t.Errorf("IsOptimalNonPreemptive() = %v, %v, want %v, %v", optimal, better, tt.wantOptimal, tt.wantBetter)
+			}
+		})
+	}
+
+	if optimal, better := IsOptimalNonPreemptive(GenerateProcesses(maxOptimalSearch+1, 1), nil); optimal || better != nil {
+		t.Errorf("IsOptimalNonPreemptive() over the search limit = %v, %v, want false, nil", optimal, better)
+	}
+}
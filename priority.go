@@ -0,0 +1,247 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// PriorityOrder is which end of the priority scale a scheduler runs first.
+type PriorityOrder int
+
+const (
+	// DefaultPriorityOrder keeps the scheduler's own convention.
+	DefaultPriorityOrder PriorityOrder = iota
+	// LowerFirst runs the smallest priority number first.
+	LowerFirst
+	// HigherFirst runs the largest priority number first.
+	HigherFirst
+)
+
+// beats reports whether priority a runs before priority b in this order, falling back to the
+// scheduler's convention for DefaultPriorityOrder.
+func (o PriorityOrder) beats(a, b float64, convention PriorityOrder) bool {
+	if o == DefaultPriorityOrder {
+		o = convention
+	}
+	if o == HigherFirst {
+		return a > b
+	}
+	return a < b
+}
+
+// PrioritySchedule outputs a preemptive priority schedule. Every tick the released, unfinished
+// process with the best priority (the lowest number, unless opts.PriorityOrder says otherwise)
+// runs, ties broken by arrival then PID.
+//
+// Processes may share a single resource (see Process.LockAt and Process.LockFor). A process that
+// needs the resource while another holds it blocks, and the holder inherits the best priority of
+// the processes it blocks until it releases the resource, so a medium-priority process cannot
+// starve a high-priority one through a low-priority holder. Each inheritance is reported as a note.
+//
+// With opts.Aging set, a process's effective priority improves by one step for every opts.Aging
+// ticks it waits, so a low-priority process can't starve, and falls back to its own priority
+// whenever it runs.
+func PrioritySchedule(ctx context.Context, w io.Writer, title string, processes []Process, opts SchedulerOptions) ScheduleResult {
+	var (
+		executed   = make([]int64, len(processes)) // burst run so far, used to place the lock interval
+		waited     = make([]int64, len(processes)) // ticks waited since last running, for aging
+		effective  = make([]float64, len(processes))
+		holder     = -1    // index of the process holding the resource
+		inherited  float64 // priority the holder last inherited, to report each change once
+		inheriting bool    // the holder inherited a priority last tick
+		protected  bool    // the current non-preemptible process already kept the CPU this dispatch
+	)
+	step := float64(-1) // an aging step towards the priority that runs first
+	if opts.PriorityOrder == HigherFirst {
+		step = 1
+	}
+
+	needsLock := func(i int) bool {
+		p := processes[i]
+		return p.LockFor > 0 && executed[i] >= p.LockAt && executed[i] < p.LockAt+p.LockFor
+	}
+
+	next := func(s *tickSim) int {
+		// the holder runs at the best priority among the processes blocked on the resource
+		for i := range processes {
+			effective[i] = s.pd[i].EffectivePriority
+		}
+		if holder >= 0 {
+			donor := -1
+			for i := range processes {
+				if i != holder && s.ready(i) && needsLock(i) && opts.PriorityOrder.beats(s.pd[i].EffectivePriority, effective[holder], LowerFirst) {
+					effective[holder] = s.pd[i].EffectivePriority
+					donor = i
+				}
+			}
+			if donor >= 0 && (!inheriting || effective[holder] != inherited) {
+				s.notes = append(s.notes, fmt.Sprintf("t=%d P%d inherited priority %s from P%d",
+					s.time, processes[holder].ProcessID, formatPriority(effective[holder]), processes[donor].ProcessID))
+			}
+			inherited, inheriting = effective[holder], donor >= 0
+		}
+
+		next := -1
+		for i := range processes {
+			if !s.ready(i) || (needsLock(i) && holder >= 0 && holder != i) {
+				continue // not available, or blocked on the resource
+			}
+			if next < 0 || opts.PriorityOrder.beats(effective[i], effective[next], LowerFirst) ||
+				(effective[i] == effective[next] && processes[i].ArrivalTime < processes[next].ArrivalTime) ||
+				(effective[i] == effective[next] && processes[i].ArrivalTime == processes[next].ArrivalTime && processes[i].ProcessID < processes[next].ProcessID) {
+				next = i
+			}
+		}
+		current := s.current
+		if current >= 0 && next != current && processes[current].NonPreemptible && !(needsLock(current) && holder >= 0 && holder != current) {
+			if !protected { // report each protected dispatch once
+				s.notes = append(s.notes, nonPreemptibleNote(s.time, processes[current], processes[next]))
+				protected = true
+			}
+			next = current
+		}
+		if next != current {
+			protected = false
+		}
+		return next
+	}
+
+	s := runTicks(ctx, title, processes, opts, tickPolicy{
+		init: func(s *tickSim) {
+			for i := range processes {
+				s.pd[i].EffectivePriority = processes[i].Priority
+			}
+		},
+		next: next,
+		waited: func(s *tickSim, i int) {
+			waited[i]++
+			if opts.Aging > 0 && waited[i]%opts.Aging == 0 {
+				s.pd[i].EffectivePriority += step
+			}
+		},
+		ran: func(s *tickSim) {
+			current := s.current
+			waited[current] = 0
+			s.pd[current].EffectivePriority = processes[current].Priority
+			if needsLock(current) {
+				holder = current
+			}
+			executed[current]++
+			// exiting releases the resource too, should the lock interval outlast the burst
+			if holder == current && (!needsLock(current) || s.remaining[current] == 0) {
+				holder = -1 // released the resource
+				inheriting = false
+			}
+		},
+	})
+
+	res := s.result(title)
+	outputResult(w, opts, res)
+	return res
+}
+
+// ArrivalPreemptiveSchedule outputs a first-come, first-serve schedule that only preempts when
+// a process arrives: if the arriving process has a better priority (the lowest number, unless
+// opts.PriorityOrder says otherwise) than the running one, it takes over at once. Otherwise the
+// running process keeps the CPU until it exits, and the next one is the released process that
+// arrived first, ties broken by PID.
+func ArrivalPreemptiveSchedule(ctx context.Context, w io.Writer, title string, processes []Process, opts SchedulerOptions) ScheduleResult {
+	s := runTicks(ctx, title, processes, opts, tickPolicy{next: func(s *tickSim) int {
+		next := s.current
+		if next >= 0 { // only a process released right now may preempt
+			for i := range processes {
+				if s.ready(i) && releaseTime(processes[i]) == s.time && opts.PriorityOrder.beats(processes[i].Priority, processes[next].Priority, LowerFirst) {
+					next = i
+				}
+			}
+			return next
+		}
+		for i := range processes {
+			if !s.ready(i) {
+				continue
+			}
+			if next < 0 || processes[i].ArrivalTime < processes[next].ArrivalTime ||
+				(processes[i].ArrivalTime == processes[next].ArrivalTime && processes[i].ProcessID < processes[next].ProcessID) {
+				next = i
+			}
+		}
+		return next
+	}})
+
+	res := s.result(title)
+	outputResult(w, opts, res)
+	return res
+}
+
+// nonPreemptibleNote reports that a non-preemptible process kept the CPU from the process that
+// would have preempted it.
+func nonPreemptibleNote(time int64, running, blocked Process) string {
+	return fmt.Sprintf("t=%d P%d kept the CPU from P%d: non-preemptible", time, running.ProcessID, blocked.ProcessID)
+}
+
+// parseNonPreemptible marks the processes with the comma-separated IDs of spec as
+// non-preemptible, e.g. "1,3".
+func parseNonPreemptible(spec string, processes []Process) error {
+	if spec == "" {
+		return nil
+	}
+	for _, field := range strings.Split(spec, ",") {
+		pid, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%w: non-preemptible process %q is not an integer", ErrInvalidArgs, field)
+		}
+		found := false
+		for i := range processes {
+			if processes[i].ProcessID == pid {
+				processes[i].NonPreemptible = true
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("%w: non-preemptible process %d does not exist", ErrInvalidArgs, pid)
+		}
+	}
+	return nil
+}
+
+// parseLocks applies a resource lock spec of comma-separated pid:at:for entries to the processes
+// with those IDs, e.g. "1:0:3,3:0:1" means P1 holds the resource for its first 3 ticks of execution
+// and P3 for its first tick.
+func parseLocks(spec string, processes []Process) error {
+	if spec == "" {
+		return nil
+	}
+	for _, entry := range strings.Split(spec, ",") {
+		fields := strings.Split(entry, ":")
+		if len(fields) != 3 {
+			return fmt.Errorf("%w: lock %q must be pid:at:for", ErrInvalidArgs, entry)
+		}
+		var values [3]int64
+		for i, field := range fields {
+			v, err := strconv.ParseInt(field, 10, 64)
+			if err != nil || v < 0 {
+				return fmt.Errorf("%w: lock %q: %q is not a non-negative integer", ErrInvalidArgs, entry, field)
+			}
+			values[i] = v
+		}
+
+		found := false
+		for i := range processes {
+			if processes[i].ProcessID == values[0] {
+				if values[1]+values[2] > processes[i].BurstDuration {
+					return fmt.Errorf("%w: lock %q: the lock ends at tick %d, past P%d's burst of %d",
+						ErrInvalidArgs, entry, values[1]+values[2], values[0], processes[i].BurstDuration)
+				}
+				processes[i].LockAt, processes[i].LockFor = values[1], values[2]
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("%w: lock %q: no process with ID %d", ErrInvalidArgs, entry, values[0])
+		}
+	}
+	return nil
+}
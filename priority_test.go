@@ -0,0 +1,267 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestPrioritySchedule(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		opts      SchedulerOptions
+		wantGantt []TimeSlice
+		wantNotes []string
+	}{
+		{
+			name: "lower number runs first",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3, Priority: 2},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2, Priority: 1},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1},
+				{PID: 2, Start: 1, Stop: 3},
+				{PID: 1, Start: 3, Stop: 5},
+			},
+		},
+		{
+			name: "higher number runs first",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3, Priority: 1},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2, Priority: 2},
+			},
+			opts: SchedulerOptions{PriorityOrder: HigherFirst},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1},
+				{PID: 2, Start: 1, Stop: 3},
+				{PID: 1, Start: 3, Stop: 5},
+			},
+		},
+		{
+			name: "fractional priorities",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3, Priority: 1},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2, Priority: 1.5},
+				{ProcessID: 3, ArrivalTime: 1, BurstDuration: 1, Priority: 0.75},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1},
+				{PID: 3, Start: 1, Stop: 2},
+				{PID: 1, Start: 2, Stop: 4},
+				{PID: 2, Start: 4, Stop: 6},
+			},
+		},
+		{
+			name: "priority inheritance",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4, Priority: 3, LockAt: 0, LockFor: 3},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3, Priority: 2},
+				{ProcessID: 3, ArrivalTime: 2, BurstDuration: 2, Priority: 1, LockAt: 0, LockFor: 1},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1},
+				{PID: 2, Start: 1, Stop: 2},
+				{PID: 1, Start: 2, Stop: 4},
+				{PID: 3, Start: 4, Stop: 6},
+				{PID: 2, Start: 6, Stop: 8},
+				{PID: 1, Start: 8, Stop: 9},
+			},
+			wantNotes: []string{"t=2 P1 inherited priority 1 from P3"},
+		},
+		{
+			name: "inheriting a negative priority",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4, Priority: 3, LockAt: 0, LockFor: 3},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3, Priority: 2},
+				{ProcessID: 3, ArrivalTime: 2, BurstDuration: 2, Priority: -1, LockAt: 0, LockFor: 1},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1},
+				{PID: 2, Start: 1, Stop: 2},
+				{PID: 1, Start: 2, Stop: 4},
+				{PID: 3, Start: 4, Stop: 6},
+				{PID: 2, Start: 6, Stop: 8},
+				{PID: 1, Start: 8, Stop: 9},
+			},
+			wantNotes: []string{"t=2 P1 inherited priority -1 from P3"},
+		},
+		{
+			name: "exiting releases the resource",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, Priority: 5, LockAt: 0, LockFor: 5},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3, Priority: 1, LockAt: 0, LockFor: 1},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2},
+				{PID: 2, Start: 2, Stop: 5},
+			},
+			wantNotes: []string{"t=1 P1 inherited priority 1 from P2"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			res := PrioritySchedule(context.Background(), io.Discard, tt.name, tt.processes, tt.opts)
+			if !reflect.DeepEqual(res.Gantt, tt.wantGantt) {
+				t.Errorf("Gantt = %v, want %v", res.Gantt, tt.wantGantt)
+			}
+			if !reflect.DeepEqual(res.Notes, tt.wantNotes) {
+				t.Errorf("Notes = %v, want %v", res.Notes, tt.wantNotes)
+			}
+		})
+	}
+}
+
+func Test_parseLocks(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: 1, BurstDuration: 3}, {ProcessID: 3, BurstDuration: 3}}
+	if err := parseLocks("1:0:3,3:2:1", processes); err != nil {
+		t.Fatal(err)
+	}
+	want := []Process{{ProcessID: 1, BurstDuration: 3, LockAt: 0, LockFor: 3}, {ProcessID: 3, BurstDuration: 3, LockAt: 2, LockFor: 1}}
+	if !reflect.DeepEqual(processes, want) {
+		t.Errorf("parseLocks() = %v, want %v", processes, want)
+	}
+
+	// the lock must end within the burst, or the holder would exit holding it
+	for _, spec := range []string{"1:0", "2:0:1", "1:x:1", "1:1:3"} {
+		if err := parseLocks(spec, processes); !errors.Is(err, ErrInvalidArgs) {
+			t.Errorf("parseLocks(%q) error = %v, want %v", spec, err, ErrInvalidArgs)
+		}
+	}
+}
+
+func Test_parseNonPreemptible(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: 1}, {ProcessID: 2}, {ProcessID: 3}}
+	if err := parseNonPreemptible("1,3", processes); err != nil {
+		t.Fatal(err)
+	}
+	want := []Process{{ProcessID: 1, NonPreemptible: true}, {ProcessID: 2}, {ProcessID: 3, NonPreemptible: true}}
+	if !reflect.DeepEqual(processes, want) {
+		t.Errorf("parseNonPreemptible() = %v, want %v", processes, want)
+	}
+
+	for _, spec := range []string{"4", "1,x"} {
+		if err := parseNonPreemptible(spec, processes); !errors.Is(err, ErrInvalidArgs) {
+			t.Errorf("parseNonPreemptible(%q) error = %v, want %v", spec, err, ErrInvalidArgs)
+		}
+	}
+}
+
+func TestArrivalPreemptiveSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4, Priority: 3},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3, Priority: 2},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 2, Priority: 1},
+		{ProcessID: 4, ArrivalTime: 3, BurstDuration: 1, Priority: 4},
+	}
+	// each arrival preempts a worse priority, but once P3 exits the CPU goes back to the first
+	// arrival rather than the best priority
+	want := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 1},
+		{PID: 2, Start: 1, Stop: 2},
+		{PID: 3, Start: 2, Stop: 4},
+		{PID: 1, Start: 4, Stop: 7},
+		{PID: 2, Start: 7, Stop: 9},
+		{PID: 4, Start: 9, Stop: 10},
+	}
+	res := ArrivalPreemptiveSchedule(context.Background(), io.Discard, "Arrival-preemptive priority", processes, SchedulerOptions{})
+	if !reflect.DeepEqual(res.Gantt, want) {
+		t.Errorf("Gantt = %v, want %v", res.Gantt, want)
+	}
+
+	// the fully preemptive schedule picks the best priority again whenever P3 exits
+	wantPreemptive := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 1},
+		{PID: 2, Start: 1, Stop: 2},
+		{PID: 3, Start: 2, Stop: 4},
+		{PID: 2, Start: 4, Stop: 6},
+		{PID: 1, Start: 6, Stop: 9},
+		{PID: 4, Start: 9, Stop: 10},
+	}
+	preemptive := PrioritySchedule(context.Background(), io.Discard, "Preemptive priority", processes, SchedulerOptions{})
+	if !reflect.DeepEqual(preemptive.Gantt, wantPreemptive) {
+		t.Errorf("PrioritySchedule Gantt = %v, want %v", preemptive.Gantt, wantPreemptive)
+	}
+}
+
+func TestPriorityScheduleStarvation(t *testing.T) {
+	t.Parallel()
+	// P1 is ready from t=0, but a better-priority process is always ready until t=9
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, Priority: 5},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 3, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 3, BurstDuration: 3, Priority: 1},
+		{ProcessID: 4, ArrivalTime: 6, BurstDuration: 3, Priority: 1},
+	}
+	want := []TimeSlice{
+		{PID: 2, Start: 0, Stop: 3},
+		{PID: 3, Start: 3, Stop: 6},
+		{PID: 4, Start: 6, Stop: 9},
+		{PID: 1, Start: 9, Stop: 11},
+	}
+	res := PrioritySchedule(context.Background(), io.Discard, "Preemptive priority", processes, SchedulerOptions{})
+	if !reflect.DeepEqual(res.Gantt, want) {
+		t.Errorf("Gantt = %v, want %v", res.Gantt, want)
+	}
+	if res.Data[0].TotalWait != 9 {
+		t.Errorf("P1 wait = %d, want 9: it only runs once no better priority is ready", res.Data[0].TotalWait)
+	}
+
+	// flipping the scale under HigherFirst starves P1 the same way, although it is the shortest job
+	for i := range processes {
+		processes[i].Priority = 6 - processes[i].Priority
+	}
+	res = PrioritySchedule(context.Background(), io.Discard, "Preemptive priority", processes, SchedulerOptions{PriorityOrder: HigherFirst})
+	if !reflect.DeepEqual(res.Gantt, want) {
+		t.Errorf("higher first Gantt = %v, want %v", res.Gantt, want)
+	}
+}
+
+func TestPriorityScheduleAging(t *testing.T) {
+	t.Parallel()
+	// without aging a better-priority process is always ready until t=15, starving P1
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, Priority: 5},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 3, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 3, BurstDuration: 3, Priority: 1},
+		{ProcessID: 4, ArrivalTime: 6, BurstDuration: 3, Priority: 1},
+		{ProcessID: 5, ArrivalTime: 9, BurstDuration: 3, Priority: 1},
+		{ProcessID: 6, ArrivalTime: 12, BurstDuration: 3, Priority: 1},
+	}
+	// aging every 2 ticks brings P1 to priority 1 at t=8, where it wins the tie with P4 by
+	// arriving first; once it has run it is back at 5 and waits again
+	want := []TimeSlice{
+		{PID: 2, Start: 0, Stop: 3},
+		{PID: 3, Start: 3, Stop: 6},
+		{PID: 4, Start: 6, Stop: 8},
+		{PID: 1, Start: 8, Stop: 9},
+		{PID: 4, Start: 9, Stop: 10},
+		{PID: 5, Start: 10, Stop: 13},
+		{PID: 6, Start: 13, Stop: 16},
+		{PID: 1, Start: 16, Stop: 17},
+	}
+	res := PrioritySchedule(context.Background(), io.Discard, "Preemptive priority", processes, SchedulerOptions{Aging: 2})
+	if !reflect.DeepEqual(res.Gantt, want) {
+		t.Errorf("Gantt = %v, want %v", res.Gantt, want)
+	}
+	if res.Data[0].FirstRun != 8 {
+		t.Errorf("P1 FirstRun = %d, want 8", res.Data[0].FirstRun)
+	}
+	if res.Data[0].EffectivePriority != 5 {
+		t.Errorf("P1 EffectivePriority = %v, want it reset to 5 after running", res.Data[0].EffectivePriority)
+	}
+
+	starved := PrioritySchedule(context.Background(), io.Discard, "Preemptive priority", processes, SchedulerOptions{})
+	if starved.Data[0].FirstRun != 15 {
+		t.Errorf("P1 FirstRun without aging = %d, want 15", starved.Data[0].FirstRun)
+	}
+}
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+)
+
+// algorithmReport holds the metrics the comparison report ranks and weighs an algorithm by.
+type algorithmReport struct {
+	title       string
+	partial     bool
+	avgWait     float64
+	turnaround  float64
+	throughput  float64
+	maxWait     float64
+	switches    float64
+	fairness    float64 // the schedule's ScheduleResult.FairnessIndex, as its footer shows it
+	completions int
+}
+
+// newAlgorithmReport derives the report metrics of a result from its completed processes.
+func newAlgorithmReport(res ScheduleResult) algorithmReport {
+	r := algorithmReport{
+		title:      res.Title,
+		partial:    res.Err != nil,
+		avgWait:    res.AvgWait,
+		turnaround: res.AvgTurnaround,
+		throughput: res.Throughput,
+		switches:   float64(contextSwitches(res.Gantt)),
+		fairness:   res.FairnessIndex,
+	}
+	for _, proc := range res.Data {
+		if proc.ExitTime == 0 || proc.TAround <= 0 {
+			continue
+		}
+		r.maxWait = math.Max(r.maxWait, float64(proc.TotalWait))
+		r.completions++
+	}
+	return r
+}
+
+// jainIndex is Jain's fairness index of the values, (Σx)² / (n·Σx²): 1 when they are all equal,
+// down to 1/n when one value has everything. It is zero without any positive value.
+func jainIndex(values []float64) float64 {
+	var sum, sumSquares float64
+	for _, v := range values {
+		sum += v
+		sumSquares += v * v
+	}
+	if sumSquares == 0 {
+		return 0
+	}
+	return sum * sum / (float64(len(values)) * sumSquares)
+}
+
+// reportMetric is one way the comparison report can rank the algorithms.
+type reportMetric struct {
+	name  string // as given to -report
+	label string
+	// best and worst describe the algorithms at either end of the ranking, e.g. "fewest context
+	// switches"; risk, if set, is what the worst one risks
+	best, worst, risk string
+	higherIsBetter    bool
+	value             func(algorithmReport) float64
+	format            string
+}
+
+// reportMetrics are the metrics -report ranks by and weighs against each other, in the order
+// the tradeoffs are explained.
+var reportMetrics = []reportMetric{
+	{
+		name: "wait", label: "average wait", best: "lowest average wait", worst: "highest average wait",
+		value: func(r algorithmReport) float64 { return r.avgWait }, format: "%.2f",
+	},
+	{
+		name: "turnaround", label: "average turnaround", best: "lowest average turnaround", worst: "highest average turnaround",
+		value: func(r algorithmReport) float64 { return r.turnaround }, format: "%.2f",
+	},
+	{
+		name: "throughput", label: "throughput", best: "highest throughput", worst: "lowest throughput", higherIsBetter: true,
+		value: func(r algorithmReport) float64 { return r.throughput }, format: "%.2f/t",
+	},
+	{
+		name: "max-wait", label: "max wait", best: "lowest max wait", worst: "highest max wait", risk: "risking starvation",
+		value: func(r algorithmReport) float64 { return r.maxWait }, format: "%.0f",
+	},
+	{
+		name: "switches", label: "context switches", best: "fewest context switches", worst: "most context switches",
+		value: func(r algorithmReport) float64 { return r.switches }, format: "%.0f",
+	},
+	{
+		name: "fairness", label: "fairness index", best: "best fairness index", worst: "worst fairness index", higherIsBetter: true,
+		value: func(r algorithmReport) float64 { return r.fairness }, format: "%.2f",
+	},
+}
+
+// findReportMetric returns the report metric with the given name.
+func findReportMetric(name string) (reportMetric, error) {
+	names := make([]string, len(reportMetrics))
+	for i, m := range reportMetrics {
+		if m.name == name {
+			return m, nil
+		}
+		names[i] = m.name
+	}
+	return reportMetric{}, fmt.Errorf("%w: unknown report metric %q, must be one of %s", ErrInvalidArgs, name, strings.Join(names, ", "))
+}
+
+// better reports whether a ranks strictly ahead of b.
+func (m reportMetric) better(a, b algorithmReport) bool {
+	if m.higherIsBetter {
+		return m.value(a) > m.value(b)
+	}
+	return m.value(a) < m.value(b)
+}
+
+func (m reportMetric) describe(r algorithmReport) string {
+	return fmt.Sprintf(m.format, m.value(r))
+}
+
+// extremes returns the indexes of the report with the best and the one with the worst value,
+// or -1 for an end that several reports share, since a tie is no tradeoff to explain.
+func (m reportMetric) extremes(reports []algorithmReport) (best, worst int) {
+	best, worst = 0, 0
+	for i := range reports {
+		if m.better(reports[i], reports[best]) {
+			best = i
+		}
+		if m.better(reports[worst], reports[i]) {
+			worst = i
+		}
+	}
+	unique := func(i int) int {
+		for j := range reports {
+			if j != i && m.value(reports[j]) == m.value(reports[i]) {
+				return -1
+			}
+		}
+		return i
+	}
+	return unique(best), unique(worst)
+}
+
+// rankAlgorithms orders the reports by the metric, keeping algorithm order between ties so the
+// ranking is reproducible.
+func rankAlgorithms(reports []algorithmReport, metric reportMetric) []algorithmReport {
+	ranked := append([]algorithmReport(nil), reports...)
+	sort.SliceStable(ranked, func(i, j int) bool { return metric.better(ranked[i], ranked[j]) })
+	return ranked
+}
+
+// reportTradeoffs explains the ranking in prose: what the winner pays for its first place, or which
+// algorithms it ties with for it, then which algorithm leads each other metric and what that costs
+// it.
+func reportTradeoffs(ranked []algorithmReport, primary reportMetric) []string {
+	if len(ranked) < 2 {
+		return nil
+	}
+	costs := func(i int, skip string) []string {
+		var costs []string
+		for _, m := range reportMetrics {
+			if m.name == skip {
+				continue
+			}
+			if _, worst := m.extremes(ranked); worst == i {
+				cost := fmt.Sprintf("the %s (%s)", m.worst, m.describe(ranked[i]))
+				if m.risk != "" {
+					cost += ", " + m.risk
+				}
+				costs = append(costs, cost)
+			}
+		}
+		return costs
+	}
+
+	var tied []string
+	for _, r := range ranked[1:] {
+		if primary.value(r) == primary.value(ranked[0]) {
+			tied = append(tied, r.title)
+		}
+	}
+	winner := fmt.Sprintf("%s wins %s (%s)", ranked[0].title, primary.label, primary.describe(ranked[0]))
+	if len(tied) > 0 {
+		winner = fmt.Sprintf("%s ties with %s for %s (%s)", ranked[0].title, strings.Join(tied, " and "), primary.label, primary.describe(ranked[0]))
+	}
+	if c := costs(0, primary.name); len(c) > 0 {
+		winner += " but has " + strings.Join(c, " and ")
+	}
+	lines := []string{winner}
+	for _, m := range reportMetrics {
+		best, _ := m.extremes(ranked)
+		if m.name == primary.name || best <= 0 {
+			continue // the winner leads it too, or no single algorithm does
+		}
+		line := fmt.Sprintf("%s has the %s (%s)", ranked[best].title, m.best, m.describe(ranked[best]))
+		if c := costs(best, m.name); len(c) > 0 {
+			line += " but " + strings.Join(c, " and ")
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// outputReport ranks the results by the metric and explains the tradeoffs between them.
+func outputReport(w io.Writer, results []ScheduleResult, metric reportMetric) {
+	reports := make([]algorithmReport, len(results))
+	for i, res := range results {
+		reports[i] = newAlgorithmReport(res)
+	}
+	ranked := rankAlgorithms(reports, metric)
+
+	_, _ = fmt.Fprintf(w, "Ranking by %s\n", metric.label)
+	for i, r := range ranked {
+		title := r.title
+		if r.partial {
+			title += " (partial)"
+		}
+		_, _ = fmt.Fprintf(w, "%d. %s: %s\n", i+1, title, metric.describe(r))
+	}
+	for _, line := range reportTradeoffs(ranked, metric) {
+		_, _ = fmt.Fprintln(w, line)
+	}
+}
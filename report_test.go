@@ -0,0 +1,71 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func Test_newAlgorithmReport(t *testing.T) {
+	t.Parallel()
+	res := ScheduleResult{
+		Title: "Round-robin",
+		Gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 4}},
+		Data:  []ProcessData{{ExitTime: 2, TAround: 2}, {TotalWait: 2, ExitTime: 4, TAround: 4}, {TotalWait: 4}},
+	}
+	// the turnarounds are 2 and 4, so Jain's index is 6² / (2 * 20), as the footer reports it
+	res.FairnessIndex = turnaroundFairness(res.Data)
+	got := newAlgorithmReport(res)
+	if got.maxWait != 2 || got.switches != 1 || got.completions != 2 || math.Abs(got.fairness-0.9) > 1e-9 {
+		t.Errorf("newAlgorithmReport() = %+v, want max wait 2, 1 switch, 2 completions and fairness 0.9", got)
+	}
+}
+
+func Test_reportTradeoffs(t *testing.T) {
+	t.Parallel()
+	reports := []algorithmReport{
+		{title: "Round-robin", avgWait: 5, turnaround: 9, throughput: 0.2, maxWait: 7, switches: 8, fairness: 0.95},
+		{title: "Shortest-job-first", avgWait: 3, turnaround: 7, throughput: 0.2, maxWait: 12, switches: 3, fairness: 0.6},
+		{title: "First-come, first-serve", avgWait: 5, turnaround: 9, throughput: 0.2, maxWait: 9, switches: 2, fairness: 0.7},
+	}
+	wait, err := findReportMetric("wait")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ranked := rankAlgorithms(reports, wait)
+	var titles []string
+	for _, r := range ranked {
+		titles = append(titles, r.title)
+	}
+	// ties keep the algorithm order
+	if want := []string{"Shortest-job-first", "Round-robin", "First-come, first-serve"}; !reflect.DeepEqual(titles, want) {
+		t.Errorf("rankAlgorithms() = %v, want %v", titles, want)
+	}
+
+	want := []string{
+		"Shortest-job-first wins average wait (3.00) but has the highest max wait (12), risking starvation and the worst fairness index (0.60)",
+		"Round-robin has the lowest max wait (7) but the most context switches (8)",
+		"First-come, first-serve has the fewest context switches (2)",
+		"Round-robin has the best fairness index (0.95) but the most context switches (8)",
+	}
+	if got := reportTradeoffs(ranked, wait); !reflect.DeepEqual(got, want) {
+		t.Errorf("reportTradeoffs() = %q, want %q", got, want)
+	}
+
+	// a first place shared is a tie, not a win
+	reports[2].avgWait = 3
+	want = []string{
+		"Shortest-job-first ties with First-come, first-serve for average wait (3.00) but has the highest max wait (12), risking starvation and the worst fairness index (0.60)",
+		"Round-robin has the lowest max wait (7) but the highest average wait (5.00) and the most context switches (8)",
+		"First-come, first-serve has the fewest context switches (2)",
+		"Round-robin has the best fairness index (0.95) but the highest average wait (5.00) and the most context switches (8)",
+	}
+	if got := reportTradeoffs(rankAlgorithms(reports, wait), wait); !reflect.DeepEqual(got, want) {
+		t.Errorf("reportTradeoffs() with a tie = %q, want %q", got, want)
+	}
+
+	if _, err := findReportMetric("latency"); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("findReportMetric() error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNegativeTime is wrapped by the error checkNegativeTimes returns. A negative wait, turnaround
+// or response can't happen on a real CPU, so it always points at a bug in a scheduler.
+var ErrNegativeTime = errors.New("negative time")
+
+// checkNegativeTimes returns an error naming the algorithm and process of every negative wait,
+// turnaround or response time in the results of scheduling processes.
+func checkNegativeTimes(processes []Process, results []ScheduleResult) error {
+	var errs []error
+	for _, res := range results {
+		for i, proc := range res.Data {
+			p := processes[i]
+			times := []struct {
+				name  string
+				value int64
+			}{
+				{"wait", proc.TotalWait},
+				{"turnaround", proc.TAround},
+				{"response", proc.FirstRun - p.ArrivalTime},
+			}
+			for _, tt := range times {
+				if tt.name == "response" && proc.FirstRun < 0 {
+					continue // never ran
+				}
+				if tt.value < 0 {
+					errs = append(errs, fmt.Errorf("%w: %s: P%d has %s %d", ErrNegativeTime, res.Title, p.ProcessID, tt.name, tt.value))
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// checkTickLimits returns an error naming every algorithm whose simulation ran into
+// SchedulerOptions.MaxTicks.
+func checkTickLimits(results []ScheduleResult) error {
+	var errs []error
+	for _, res := range results {
+		if errors.Is(res.Err, ErrTickLimit) {
+			errs = append(errs, fmt.Errorf("%s: %w", res.Title, res.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func Test_checkNegativeTimes(t *testing.T) {
+	t.Parallel()
+	// P2 arrives after P1 finished, so the CPU should idle until t=5
+	lateArrival := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, Priority: 1},
+		{ProcessID: 2, ArrivalTime: 5, BurstDuration: 1, Priority: 1},
+	}
+	// a scheduler that started P2 when P1 exited rather than when P2 arrived
+	eager := func(context.Context, io.Writer, string, []Process, SchedulerOptions) ScheduleResult {
+		return ScheduleResult{Title: "Eager", Data: []ProcessData{
+			{TotalWait: 0, TAround: 2, ExitTime: 2, FirstRun: 0},
+			{TotalWait: -3, TAround: -2, ExitTime: 3, FirstRun: 2},
+		}}
+	}
+	tests := []struct {
+		name     string
+		schedule func(context.Context, io.Writer, string, []Process, SchedulerOptions) ScheduleResult
+		wantErrs []string
+	}{
+		{name: "FCFS", schedule: FCFSSchedule},
+		{name: "Priority", schedule: PrioritySchedule},
+		{name: "Eager", schedule: eager, wantErrs: []string{"Eager: P2 has wait -3", "Eager: P2 has turnaround -2", "Eager: P2 has response -3"}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			res := tt.schedule(context.Background(), io.Discard, tt.name, lateArrival, SchedulerOptions{})
+			err := checkNegativeTimes(lateArrival, []ScheduleResult{res})
+			if len(tt.wantErrs) == 0 {
+				if err != nil {
+					t.Errorf("checkNegativeTimes() error = %v, want nil", err)
+				}
+				return
+			}
+			for _, want := range tt.wantErrs {
+				if !errors.Is(err, ErrNegativeTime) || !strings.Contains(err.Error(), want) {
+					t.Errorf("checkNegativeTimes() error = %v, want %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func Test_checkTickLimits(t *testing.T) {
+	t.Parallel()
+	limited := fmt.Errorf("%w: simulation exceeded 6 ticks, possible non-terminating schedule", ErrTickLimit)
+	results := []ScheduleResult{{Title: "Round-robin", Err: limited}, {Title: "Preemptive priority", Err: ErrHorizon}, {Title: "Completely fair"}}
+	err := checkTickLimits(results)
+	if !errors.Is(err, ErrTickLimit) || err.Error() != "Round-robin: "+limited.Error() {
+		t.Errorf("checkTickLimits() = %v, want only Round-robin's tick limit", err)
+	}
+	if err := checkTickLimits(results[1:]); err != nil {
+		t.Errorf("checkTickLimits() without a tick limit = %v, want nil", err)
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"io"
+)
+
+// NonPreemptiveSJFSchedule outputs a non-preemptive shortest-job-first schedule: whenever the CPU
+// is free, the released, unfinished process with the shortest burst runs to completion, ties
+// broken by arrival then PID. Unlike SRTFSchedule, a shorter process that arrives meanwhile waits
+// for the running one to exit. A process with I/O bursts is ranked by its next CPU burst, which
+// it runs until it exits or blocks.
+func NonPreemptiveSJFSchedule(ctx context.Context, w io.Writer, title string, processes []Process, opts SchedulerOptions) ScheduleResult {
+	s := runTicks(ctx, title, processes, opts, tickPolicy{next: func(s *tickSim) int {
+		if s.current >= 0 {
+			return s.current // only a free CPU picks the next process
+		}
+		next := -1
+		var shortest int64
+		for i, p := range processes {
+			if !s.ready(i) {
+				continue
+			}
+			burst := p.cpuBurstLeft(s.remaining[i])
+			if next < 0 || burst < shortest ||
+				(burst == shortest && p.ArrivalTime < processes[next].ArrivalTime) ||
+				(burst == shortest && p.ArrivalTime == processes[next].ArrivalTime && p.ProcessID < processes[next].ProcessID) {
+				next, shortest = i, burst
+			}
+		}
+		return next
+	}})
+
+	res := s.result(title)
+	outputResult(w, opts, res)
+	return res
+}
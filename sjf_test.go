@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestNonPreemptiveSJFSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 3},
+		{ProcessID: 4, ArrivalTime: 2, BurstDuration: 3},
+	}
+	// P2 arrives during P1's burst but waits for it to exit; P3 and P4 tie on burst and arrival
+	want := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 5},
+		{PID: 2, Start: 5, Stop: 6},
+		{PID: 3, Start: 6, Stop: 9},
+		{PID: 4, Start: 9, Stop: 12},
+	}
+	res := NonPreemptiveSJFSchedule(context.Background(), io.Discard, "Shortest-job-first", processes, SchedulerOptions{})
+	if !reflect.DeepEqual(res.Gantt, want) {
+		t.Errorf("Gantt = %v, want %v", res.Gantt, want)
+	}
+	if res.AvgWait != 15.0/4 {
+		t.Errorf("AvgWait = %v, want %v", res.AvgWait, 15.0/4)
+	}
+}
+
+func TestNonPreemptiveSJFScheduleDiffersFromSRTF(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1},
+	}
+	tests := []struct {
+		name      string
+		schedule  func(context.Context, io.Writer, string, []Process, SchedulerOptions) ScheduleResult
+		wantGantt []TimeSlice
+		wantWait  float64
+	}{
+		{
+			// P2 waits for P1 to exit
+			name:      "SJF",
+			schedule:  NonPreemptiveSJFSchedule,
+			wantGantt: []TimeSlice{{PID: 1, Start: 0, Stop: 5}, {PID: 2, Start: 5, Stop: 6}},
+			wantWait:  2,
+		},
+		{
+			// P2 preempts P1 as soon as it arrives
+			name:      "SRTF",
+			schedule:  SRTFSchedule,
+			wantGantt: []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 2}, {PID: 1, Start: 2, Stop: 6}},
+			wantWait:  0.5,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			res := tt.schedule(context.Background(), io.Discard, tt.name, processes, SchedulerOptions{})
+			if !reflect.DeepEqual(res.Gantt, tt.wantGantt) {
+				t.Errorf("Gantt = %v, want %v", res.Gantt, tt.wantGantt)
+			}
+			if res.AvgWait != tt.wantWait {
+				t.Errorf("AvgWait = %v, want %v", res.AvgWait, tt.wantWait)
+			}
+		})
+	}
+}
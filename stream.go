@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// ScheduleStream runs the algorithm with the given name (see algorithms) in the background with the
+// default options and sends each slice of its Gantt chart on the first channel as soon as the
+// simulation completes it, for front-ends that animate a schedule as it unfolds. Once the
+// simulation ends the slice channel is closed and the whole result is sent on the second channel,
+// which is then closed too.
+//
+// The simulation waits for each slice to be received, so a slow reader paces it. Cancelling ctx
+// stops it early with a partial result (see ScheduleResult.Err), even if nobody reads the slices
+// any more. An unknown algorithm sends no slices and a result carrying an ErrInvalidArgs error.
+func ScheduleStream(ctx context.Context, processes []Process, algo string) (<-chan TimeSlice, <-chan ScheduleResult) {
+	slices := make(chan TimeSlice)
+	results := make(chan ScheduleResult, 1)
+
+	var schedule Scheduler = SchedulerFunc(func(context.Context, io.Writer, string, []Process, SchedulerOptions) ScheduleResult {
+		return ScheduleResult{Err: fmt.Errorf("%w: unknown algorithm %q", ErrInvalidArgs, algo)}
+	})
+	title := algo
+	for _, a := range algorithms {
+		if a.name == algo {
+			schedule, title = a.schedule, a.title
+		}
+	}
+	opts := SchedulerOptions{
+		ShowWeight: hasWeights(processes),
+		sliceHook: func(slice TimeSlice) {
+			select {
+			case slices <- slice:
+			case <-ctx.Done():
+			}
+		},
+	}
+
+	go func() {
+		res := schedule.Schedule(ctx, io.Discard, title, processes, opts)
+		close(slices)
+		results <- res
+		close(results)
+	}()
+	return slices, results
+}
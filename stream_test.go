@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestScheduleStream(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+	}
+	for _, algo := range algorithms {
+		algo := algo
+		t.Run(algo.name, func(t *testing.T) {
+			t.Parallel()
+			slices, results := ScheduleStream(context.Background(), processes, algo.name)
+			var streamed []TimeSlice
+			for slice := range slices {
+				streamed = append(streamed, slice)
+			}
+			res := <-results
+			if res.Err != nil {
+				t.Fatal(res.Err)
+			}
+			if res.Title != algo.title {
+				t.Errorf("Title = %q, want %q", res.Title, algo.title)
+			}
+			if !reflect.DeepEqual(streamed, res.Gantt) {
+				t.Errorf("streamed %v, want the result's Gantt %v", streamed, res.Gantt)
+			}
+		})
+	}
+}
+
+func TestScheduleStreamCancelled(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	slices, results := ScheduleStream(ctx, GenerateProcesses(50, 1), "priority")
+	<-slices // stop reading after the first slice
+	cancel()
+	if res := <-results; !errors.Is(res.Err, context.Canceled) {
+		t.Errorf("Err = %v, want %v", res.Err, context.Canceled)
+	}
+
+	slices, results = ScheduleStream(context.Background(), nil, "lottery")
+	if _, ok := <-slices; ok {
+		t.Error("an unknown algorithm streamed a slice")
+	}
+	if res := <-results; !errors.Is(res.Err, ErrInvalidArgs) {
+		t.Errorf("unknown algorithm Err = %v, want %v", res.Err, ErrInvalidArgs)
+	}
+}
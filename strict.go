@@ -0,0 +1,53 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+)
+
+// ErrStrict is wrapped by the error strict mode returns for tolerated anomalies.
+var ErrStrict = errors.New("strict mode")
+
+// addStrictFlag registers the -strict flag that checkAnomalies obeys.
+func addStrictFlag(fs *flag.FlagSet) *bool {
+	return fs.Bool("strict", false, "fail on any tolerated anomaly: a skipped header row, empty priority cells, duplicate process IDs, idle CPU time")
+}
+
+// checkAnomalies returns an error listing every anomaly in strict mode, and otherwise logs each
+// one as a warning. Anomalies are oddities in the input or the simulation that are tolerated
+// by default:
+//   - a header row, which is skipped
+//   - an empty priority cell, which defaults to 0
+//   - a process ID that repeats an earlier row's
+//   - the CPU sitting idle at any point of a schedule
+func checkAnomalies(logger *slog.Logger, strict bool, anomalies []string) error {
+	if len(anomalies) == 0 {
+		return nil
+	}
+	if strict {
+		errs := make([]error, len(anomalies))
+		for i, anomaly := range anomalies {
+			errs[i] = errors.New(anomaly)
+		}
+		return fmt.Errorf("%w: %d anomalies:\n%w", ErrStrict, len(anomalies), errors.Join(errs...))
+	}
+	for _, anomaly := range anomalies {
+		logger.Warn(anomaly)
+	}
+	return nil
+}
+
+// idleAnomalies reports every stretch of a schedule where no process ran.
+func idleAnomalies(results []ScheduleResult) []string {
+	var anomalies []string
+	for _, res := range results {
+		for _, slice := range withIdle(res.Gantt) {
+			if slice.PID == IdlePID {
+				anomalies = append(anomalies, fmt.Sprintf("%s: CPU idle from t=%d to t=%d", res.Title, slice.Start, slice.Stop))
+			}
+		}
+	}
+	return anomalies
+}
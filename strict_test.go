@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_readProcessesAnomalies(t *testing.T) {
+	t.Parallel()
+	_, anomalies, err := readProcesses(strings.NewReader(`1,5,0,
+2,9,3,1
+1,6,3,3`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"row 1: empty priority defaulted to 0",
+		"row 3: process ID 1 duplicates row 1",
+	}
+	if !reflect.DeepEqual(anomalies, want) {
+		t.Errorf("readProcesses() anomalies = %v, want %v", anomalies, want)
+	}
+
+	// a header row still counts in the row numbers, and skipping it is an anomaly too
+	_, anomalies, err = readProcesses(strings.NewReader(`id,burst,arrival,priority
+1,5,0,
+1,6,3,3`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = []string{
+		"row 1: header row skipped",
+		"row 2: empty priority defaulted to 0",
+		"row 3: process ID 1 duplicates row 2",
+	}
+	if !reflect.DeepEqual(anomalies, want) {
+		t.Errorf("readProcesses() with a header anomalies = %v, want %v", anomalies, want)
+	}
+}
+
+func Test_idleAnomalies(t *testing.T) {
+	t.Parallel()
+	results := []ScheduleResult{
+		{Title: "busy", Gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 4}}},
+		{Title: "gaps", Gantt: []TimeSlice{{PID: 1, Start: 1, Stop: 2}, {PID: 2, Start: 4, Stop: 5}}},
+	}
+	want := []string{
+		"gaps: CPU idle from t=0 to t=1",
+		"gaps: CPU idle from t=2 to t=4",
+	}
+	if got := idleAnomalies(results); !reflect.DeepEqual(got, want) {
+		t.Errorf("idleAnomalies() = %v, want %v", got, want)
+	}
+}
+
+func Test_checkAnomalies(t *testing.T) {
+	t.Parallel()
+	anomalies := []string{"row 1: empty priority defaulted to 0", "row 3: process ID 1 duplicates row 1"}
+
+	var w bytes.Buffer
+	logger := newLogger(&w, slog.LevelInfo)
+	if err := checkAnomalies(logger, false, anomalies); err != nil {
+		t.Errorf("lenient checkAnomalies() error = %v, want nil", err)
+	}
+	if want := "level=WARN msg=\"row 1: empty priority defaulted to 0\"\nlevel=WARN msg=\"row 3: process ID 1 duplicates row 1\"\n"; w.String() != want {
+		t.Errorf("lenient checkAnomalies() wrote %q, want %q", w.String(), want)
+	}
+
+	w.Reset()
+	err := checkAnomalies(logger, true, anomalies)
+	if !errors.Is(err, ErrStrict) {
+		t.Fatalf("strict checkAnomalies() error = %v, want %v", err, ErrStrict)
+	}
+	for _, anomaly := range anomalies {
+		if !strings.Contains(err.Error(), anomaly) {
+			t.Errorf("strict error %q does not list %q", err, anomaly)
+		}
+	}
+	if w.Len() != 0 {
+		t.Errorf("strict checkAnomalies() wrote %q, want nothing", w.String())
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"io"
+)
+
+const (
+	// svgChartWidth is how many pixels the time axis of an SVG Gantt chart spans, however long
+	// the schedule, so slices stay proportional to their length.
+	svgChartWidth = 800
+	svgMargin     = 20 // pixels around the chart
+	svgBarHeight  = 40
+	svgHeight     = 80 // of one chart: the bars, the tick marks and their labels
+	svgTitleGap   = 24 // between an algorithm's title and its chart in writeResultsSVG
+)
+
+// svgColors fill the slices of each PID in turn; idle time is grey.
+var svgColors = []string{"#4e79a7", "#f28e2b", "#e15759", "#76b7b2", "#59a14f", "#edc948", "#b07aa1", "#ff9da7"}
+
+const svgIdleColor = "#d3d3d3"
+
+// outputGanttSVG draws the Gantt chart as a standalone SVG image: a horizontal bar per time slice
+// scaled to its length, labelled with its PID at the centre, idle gaps in grey, and a time axis
+// with a tick mark and label at every slice boundary.
+func outputGanttSVG(w io.Writer, gantt []TimeSlice) {
+	gantt = withIdle(gantt)
+	var end int64
+	if len(gantt) > 0 {
+		end = gantt[len(gantt)-1].Stop
+	}
+	scale := float64(svgChartWidth)
+	if end > 0 {
+		scale /= float64(end)
+	}
+	x := func(t int64) float64 { return svgMargin + float64(t)*scale }
+
+	_, _ = fmt.Fprintf(w, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\">\n",
+		svgChartWidth+2*svgMargin, svgHeight, svgChartWidth+2*svgMargin, svgHeight)
+	for _, slice := range gantt {
+		fill, label := svgIdleColor, "idle"
+		if slice.PID != IdlePID {
+			n := int64(len(svgColors))
+			fill, label = svgColors[(slice.PID%n+n)%n], fmt.Sprintf("P%d", slice.PID)
+		}
+		_, _ = fmt.Fprintf(w, "  <rect x=\"%.2f\" y=\"%d\" width=\"%.2f\" height=\"%d\" fill=\"%s\" stroke=\"#000\"/>\n",
+			x(slice.Start), svgMargin/2, x(slice.Stop)-x(slice.Start), svgBarHeight, fill)
+		_, _ = fmt.Fprintf(w, "  <text x=\"%.2f\" y=\"%d\" text-anchor=\"middle\" dominant-baseline=\"middle\" font-family=\"sans-serif\" font-size=\"12\">%s</text>\n",
+			(x(slice.Start)+x(slice.Stop))/2, svgMargin/2+svgBarHeight/2, label)
+	}
+
+	axis := svgMargin/2 + svgBarHeight
+	_, _ = fmt.Fprintf(w, "  <line x1=\"%.2f\" y1=\"%d\" x2=\"%.2f\" y2=\"%d\" stroke=\"#000\"/>\n", x(0), axis, x(end), axis)
+	boundaries := []int64{0}
+	for _, slice := range gantt {
+		boundaries = append(boundaries, slice.Stop)
+	}
+	for _, t := range boundaries {
+		_, _ = fmt.Fprintf(w, "  <line x1=\"%.2f\" y1=\"%d\" x2=\"%.2f\" y2=\"%d\" stroke=\"#000\"/>\n", x(t), axis, x(t), axis+6)
+		_, _ = fmt.Fprintf(w, "  <text x=\"%.2f\" y=\"%d\" text-anchor=\"middle\" font-family=\"sans-serif\" font-size=\"10\">%d</text>\n", x(t), axis+18, t)
+	}
+	_, _ = fmt.Fprintln(w, "</svg>")
+}
+
+// writeResultsSVG writes the results as one SVG image with every algorithm's title over its
+// Gantt chart, in algorithm order.
+func writeResultsSVG(w io.Writer, results []ScheduleResult) error {
+	block := svgTitleGap + svgHeight
+	_, err := fmt.Fprintf(w, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\">\n",
+		svgChartWidth+2*svgMargin, len(results)*block)
+	if err != nil {
+		return err
+	}
+	for i, res := range results {
+		_, _ = fmt.Fprintf(w, "<text x=\"%d\" y=\"%d\" font-family=\"sans-serif\" font-size=\"14\" font-weight=\"bold\">%s</text>\n",
+			svgMargin, i*block+svgTitleGap-6, html.EscapeString(res.Title))
+		_, _ = fmt.Fprintf(w, "<g transform=\"translate(0,%d)\">\n", i*block+svgTitleGap)
+		outputGanttSVG(w, res.Gantt)
+		_, _ = fmt.Fprintln(w, "</g>")
+	}
+	_, err = fmt.Fprintln(w, "</svg>")
+	return err
+}
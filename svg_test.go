@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_outputGanttSVG(t *testing.T) {
+	t.Parallel()
+	// a one-tick slice next to a long burst, with an idle gap between them
+	gantt := []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 2, Stop: 100}}
+	var w bytes.Buffer
+	outputGanttSVG(&w, gantt)
+	got := w.String()
+	for _, want := range []string{
+		`<rect x="20.00" y="10" width="8.00" height="40" fill="#f28e2b" stroke="#000"/>`,
+		`<rect x="28.00" y="10" width="8.00" height="40" fill="#d3d3d3" stroke="#000"/>`,
+		`<rect x="36.00" y="10" width="784.00" height="40" fill="#e15759" stroke="#000"/>`,
+		`font-size="12">idle</text>`,
+		`<text x="428.00" y="30" text-anchor="middle" dominant-baseline="middle" font-family="sans-serif" font-size="12">P2</text>`,
+		`<text x="820.00" y="68" text-anchor="middle" font-family="sans-serif" font-size="10">100</text>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("outputGanttSVG() is missing %s:\n%s", want, got)
+		}
+	}
+	for _, boundary := range []string{">0</text>", ">1</text>", ">2</text>", ">100</text>"} {
+		if strings.Count(got, boundary) != 1 {
+			t.Errorf("outputGanttSVG() should label boundary %s once:\n%s", boundary, got)
+		}
+	}
+	if !strings.HasPrefix(got, "<svg ") || !strings.HasSuffix(got, "</svg>\n") {
+		t.Errorf("outputGanttSVG() is not one svg element:\n%s", got)
+	}
+}
+
+func Test_writeResultsSVG(t *testing.T) {
+	t.Parallel()
+	results := []ScheduleResult{
+		{Title: "Round-robin", Gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2}}},
+		{Title: "A & B", Gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2}}},
+	}
+	var w bytes.Buffer
+	if err := writeResults(&w, "svg", results); err != nil {
+		t.Fatal(err)
+	}
+	got := w.String()
+	if n := strings.Count(got, "<svg "); n != 3 {
+		t.Errorf("writeResults(svg) has %d svg elements, want one around a chart per result:\n%s", n, got)
+	}
+	for _, want := range []string{`height="208"`, `>A &amp; B</text>`, `<g transform="translate(0,128)">`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("writeResults(svg) is missing %s:\n%s", want, got)
+		}
+	}
+}
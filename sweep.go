@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// maxSweepQuantum bounds the quantum sweep so huge bursts don't run hundreds of simulations.
+const maxSweepQuantum = 100
+
+// QuantumRun is the round-robin outcome for one quantum of a sweep.
+type QuantumRun struct {
+	Quantum         int64
+	AvgTurnaround   float64
+	ContextSwitches int
+}
+
+// quantumSweep runs round-robin once for each quantum from 1 to the longest burst (at most
+// maxSweepQuantum), stopping early if the context is cancelled.
+func quantumSweep(ctx context.Context, processes []Process, opts SchedulerOptions) []QuantumRun {
+	var longest int64
+	for i := range processes {
+		if processes[i].BurstDuration > longest {
+			longest = processes[i].BurstDuration
+		}
+	}
+	if longest > maxSweepQuantum {
+		longest = maxSweepQuantum
+	}
+
+	runs := make([]QuantumRun, 0, longest)
+	for q := int64(1); q <= longest; q++ {
+		opts.Quantum = q
+		m, err := metrics(ctx, "rr", processes, opts)
+		if err != nil || m.Err != nil {
+			break
+		}
+		runs = append(runs, QuantumRun{
+			Quantum:         q,
+			AvgTurnaround:   m.AvgTurnaround,
+			ContextSwitches: m.ContextSwitches,
+		})
+	}
+	return runs
+}
+
+// bestQuanta returns the runs with the lowest average turnaround and the fewest context
+// switches, preferring the smaller quantum on ties.
+func bestQuanta(runs []QuantumRun) (turnaround, switches QuantumRun) {
+	turnaround.AvgTurnaround = math.Inf(1)
+	switches.ContextSwitches = math.MaxInt
+	for _, run := range runs {
+		if run.AvgTurnaround < turnaround.AvgTurnaround {
+			turnaround = run
+		}
+		if run.ContextSwitches < switches.ContextSwitches {
+			switches = run
+		}
+	}
+	return turnaround, switches
+}
+
+func outputQuantumSweep(w io.Writer, runs []QuantumRun) {
+	outputTitle(w, "Round-robin quantum sweep")
+	if len(runs) == 0 {
+		_, _ = fmt.Fprintln(w, "No quanta were simulated")
+		return
+	}
+
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Quantum", "Avg turnaround", "Context switches"})
+	for _, run := range runs {
+		table.Append([]string{
+			fmt.Sprint(run.Quantum),
+			fmt.Sprintf("%.2f", run.AvgTurnaround),
+			fmt.Sprint(run.ContextSwitches),
+		})
+	}
+	table.Render()
+
+	turnaround, switches := bestQuanta(runs)
+	_, _ = fmt.Fprintf(w, "Best quantum for average turnaround: %d (%.2f)\n", turnaround.Quantum, turnaround.AvgTurnaround)
+	_, _ = fmt.Fprintf(w, "Best quantum for context switches: %d (%d)\n", switches.Quantum, switches.ContextSwitches)
+}
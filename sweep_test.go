@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func Test_quantumSweep(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2},
+	}
+	runs := quantumSweep(context.Background(), processes, SchedulerOptions{})
+	if len(runs) != 3 {
+		t.Fatalf("quantumSweep() ran %d quanta, want 3", len(runs))
+	}
+	for i, run := range runs {
+		if run.Quantum != int64(i+1) {
+			t.Errorf("runs[%d].Quantum = %d, want %d", i, run.Quantum, i+1)
+		}
+	}
+	if runs[0].ContextSwitches <= runs[2].ContextSwitches {
+		t.Errorf("quantum 1 switched %d times, want more than quantum 3 (%d)", runs[0].ContextSwitches, runs[2].ContextSwitches)
+	}
+}
+
+func Test_bestQuanta(t *testing.T) {
+	t.Parallel()
+	runs := []QuantumRun{
+		{Quantum: 1, AvgTurnaround: 9, ContextSwitches: 6},
+		{Quantum: 2, AvgTurnaround: 7, ContextSwitches: 3},
+		{Quantum: 3, AvgTurnaround: 7, ContextSwitches: 1},
+		{Quantum: 4, AvgTurnaround: 8, ContextSwitches: 1},
+	}
+	turnaround, switches := bestQuanta(runs)
+	if !reflect.DeepEqual(turnaround, runs[1]) {
+		t.Errorf("best turnaround = %v, want %v", turnaround, runs[1])
+	}
+	if !reflect.DeepEqual(switches, runs[2]) {
+		t.Errorf("best switches = %v, want %v", switches, runs[2])
+	}
+}
+
+func Test_contextSwitches(t *testing.T) {
+	t.Parallel()
+	gantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 2},
+		{PID: 2, Start: 2, Stop: 2},
+		{PID: 1, Start: 2, Stop: 4},
+		{PID: 3, Start: 4, Stop: 6},
+	}
+	if got := contextSwitches(gantt); got != 1 {
+		t.Errorf("contextSwitches() = %d, want 1", got)
+	}
+}
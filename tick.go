@@ -0,0 +1,173 @@
+package main
+
+import "context"
+
+// tickSim is the state of a tick-based simulation that runTicks drives and a tickPolicy reads to
+// pick the next process.
+type tickSim struct {
+	processes  []Process
+	opts       SchedulerOptions
+	remaining  []int64 // burst left to run, penalties included
+	pd         []ProcessData
+	gantt      []TimeSlice
+	blocker    ioBlocker
+	notes      []string // reported before the result's own notes
+	lostWork   int64
+	dispatched int64 // work done by the current process since it was dispatched
+	current    int   // index of the running process, -1 while idle
+	finished   int
+	time       int64
+	err        error          // why the simulation stopped early, if it did
+	burndown   []BurndownTick // see SchedulerOptions.Burndown
+}
+
+// ready reports whether process i can run the tick starting at s.time: released, unfinished and
+// not blocked on I/O.
+func (s *tickSim) ready(i int) bool {
+	return s.pd[i].ExitTime == 0 && releaseTime(s.processes[i]) <= s.time && !s.blocker.blocked(i, s.time)
+}
+
+// tickPolicy is what sets one tick-based scheduler apart; runTicks does everything else.
+type tickPolicy struct {
+	// init, if set, is called once before the first tick.
+	init func(s *tickSim)
+	// next returns the process to run the tick starting at s.time, or -1 to idle. s.current is the
+	// process that ran the last tick, or -1 if none did or it exited or blocked on I/O at its end;
+	// returning another process preempts it.
+	next func(s *tickSim) int
+	// waited, if set, is called for every process that waits the tick after next chose.
+	waited func(s *tickSim, i int)
+	// ran, if set, is called after s.current ran a tick, before it may exit or block.
+	ran func(s *tickSim)
+}
+
+// runTicks simulates processes tick by tick under policy: it dispatches and preempts along the
+// Gantt chart, applying opts.PreemptPenalty to every preempted process, accrues waiting time,
+// blocks processes on their I/O bursts, traces every tick and stops early when ctx is done or
+// opts.Horizon or opts.MaxTicks is reached.
+func runTicks(ctx context.Context, title string, processes []Process, opts SchedulerOptions, policy tickPolicy) *tickSim {
+	var (
+		clock = opts.clock()
+		s     = &tickSim{
+			processes: processes,
+			opts:      opts,
+			remaining: make([]int64, len(processes)),
+			pd:        make([]ProcessData, len(processes)),
+			gantt:     make([]TimeSlice, 0),
+			current:   -1,
+			time:      clock.Now(),
+		}
+		ready = s.ready
+	)
+	s.blocker = newIOBlocker(processes, s.pd)
+	for i := range processes {
+		s.remaining[i] = processes[i].BurstDuration
+		s.pd[i].FirstRun = -1
+	}
+	if policy.init != nil {
+		policy.init(s)
+	}
+
+	for s.finished < len(processes) {
+		if s.err = checkCancelled(ctx, s.time); s.err != nil {
+			break
+		}
+		if s.err = opts.pastHorizon(s.time); s.err != nil {
+			break
+		}
+
+		next := policy.next(s)
+		preempted := -1
+		if next != s.current {
+			if s.current >= 0 { // the current process was preempted
+				preempted = s.current
+				s.gantt[len(s.gantt)-1].Stop = s.time
+				opts.emitSlice(s.gantt[len(s.gantt)-1])
+				lost := preemptionPenalty(s.dispatched, opts.PreemptPenalty)
+				s.remaining[s.current] += lost
+				s.pd[s.current].LostWork += lost
+				s.lostWork += lost
+			}
+			if next >= 0 {
+				s.gantt = append(s.gantt, TimeSlice{PID: processes[next].ProcessID, Start: s.time})
+				s.pd[next].Laxity = laxity(processes[next], s.time, s.remaining[next])
+			}
+			s.dispatched = 0
+			s.current = next
+		}
+		opts.traceTick(title, s.time, processes, s.current, preempted, ready)
+		s.recordBurndown()
+
+		for i := range processes {
+			if i != s.current && s.pd[i].ExitTime == 0 && arrived(processes[i], s.time) && !s.blocker.blocked(i, s.time) {
+				s.pd[i].TotalWait++
+				if policy.waited != nil {
+					policy.waited(s, i)
+				}
+			}
+		}
+		s.time = clock.Advance()
+		if s.current < 0 {
+			continue // idle
+		}
+
+		current := s.current
+		if s.pd[current].FirstRun < 0 {
+			s.pd[current].FirstRun = s.time - 1
+		}
+		s.remaining[current]--
+		s.dispatched++
+		if policy.ran != nil {
+			policy.ran(s)
+		}
+		if s.remaining[current] == 0 {
+			s.pd[current].ExitTime = s.time
+			s.gantt[len(s.gantt)-1].Stop = s.time
+			opts.emitSlice(s.gantt[len(s.gantt)-1])
+			s.finished++
+			s.current = -1
+		} else if s.blocker.ran(current, s.time, s.remaining[current]) { // off to I/O, freeing the CPU
+			s.gantt[len(s.gantt)-1].Stop = s.time
+			opts.emitSlice(s.gantt[len(s.gantt)-1])
+			s.current = -1
+		}
+	}
+	s.recordBurndown()                  // where the simulation stopped
+	if s.err != nil && s.current >= 0 { // close the slice that was running when cancelled
+		s.gantt[len(s.gantt)-1].Stop = s.time
+		opts.emitSlice(s.gantt[len(s.gantt)-1])
+	}
+	for i := range s.pd {
+		if s.pd[i].ExitTime == 0 {
+			s.pd[i].Remaining = s.remaining[i]
+		}
+	}
+	return s
+}
+
+// result builds the simulation's ScheduleResult (see tickResult), with its lost work and notes.
+func (s *tickSim) result(title string) ScheduleResult {
+	res := tickResult(title, s.processes, s.pd, s.gantt, s.time, s.opts, s.err)
+	res.LostWork = s.lostWork
+	res.Burndown = s.burndown
+	res.Notes = append(s.notes, res.Notes...)
+	return res
+}
+
+// recordBurndown records the remaining bursts at the start of the tick at s.time.
+func (s *tickSim) recordBurndown() {
+	s.burndown = s.opts.burndownTick(s.burndown, s.time, len(s.remaining), func(i int) int64 { return s.remaining[i] })
+}
+
+// burndownTick appends the remaining burst of each of n processes at time to burndown when
+// o.Burndown is set.
+func (o SchedulerOptions) burndownTick(burndown []BurndownTick, time int64, n int, remaining func(i int) int64) []BurndownTick {
+	if !o.Burndown {
+		return burndown
+	}
+	tick := BurndownTick{Time: time, Remaining: make([]int64, n)}
+	for i := range tick.Remaining {
+		tick.Remaining[i] = remaining(i)
+	}
+	return append(burndown, tick)
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// traceTick writes the -trace line of the tick starting at time to opts.Trace, if set: the running
+// process, or idle when running is negative, the other processes ready for taking over in input
+// order, and the process the running one just preempted, if preempted isn't negative, e.g.
+//
+//	Earliest-deadline-first t=3: running P2, ready [P1 P3], preempted P1
+func (o SchedulerOptions) traceTick(title string, time int64, processes []Process, running, preempted int, ready func(i int) bool) {
+	if o.Trace == nil {
+		return
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s t=%d: ", title, time)
+	if running >= 0 {
+		fmt.Fprintf(&b, "running P%d", processes[running].ProcessID)
+	} else {
+		b.WriteString("idle")
+	}
+	var queue []string
+	for i := range processes {
+		if i != running && ready(i) {
+			queue = append(queue, fmt.Sprintf("P%d", processes[i].ProcessID))
+		}
+	}
+	fmt.Fprintf(&b, ", ready [%s]", strings.Join(queue, " "))
+	if preempted >= 0 {
+		fmt.Fprintf(&b, ", preempted P%d", processes[preempted].ProcessID)
+	}
+	_, _ = fmt.Fprintln(o.Trace, b.String())
+}
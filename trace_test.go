@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestSchedulersTrace(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1, Priority: 1},
+	}
+	var b strings.Builder
+	res := SRTFSchedule(context.Background(), io.Discard, "SRTF", processes, SchedulerOptions{Trace: &b})
+	want := `SRTF t=0: running P1, ready []
+SRTF t=1: running P2, ready [P1], preempted P1
+SRTF t=2: running P1, ready []
+SRTF t=3: running P1, ready []
+SRTF t=4: running P1, ready []
+`
+	if b.String() != want {
+		t.Errorf("trace =\n%s\nwant\n%s", b.String(), want)
+	}
+	if len(res.Rows) != len(processes) {
+		t.Errorf("Rows = %v, want the table built as without a trace", res.Rows)
+	}
+
+	// every tick-based scheduler traces each tick up to the last exit, once
+	for _, sched := range testSchedulers {
+		if sched.name == "FCFS" {
+			continue
+		}
+		var b strings.Builder
+		sched.schedule(context.Background(), io.Discard, "trace", processes, SchedulerOptions{Trace: &b})
+		if lines := strings.Count(b.String(), "\n"); lines != 5 {
+			t.Errorf("%s traced %d ticks, want 5:\n%s", sched.name, lines, b.String())
+		}
+	}
+
+	b.Reset()
+	RRSchedule(context.Background(), io.Discard, "Round-robin", processes, SchedulerOptions{Trace: &b})
+	want = `Round-robin t=0: running P1, ready []
+Round-robin t=1: running P1, ready [P2]
+Round-robin t=2: running P2, ready [P1], preempted P1
+Round-robin t=3: running P1, ready []
+Round-robin t=4: running P1, ready []
+`
+	if b.String() != want {
+		t.Errorf("round-robin trace =\n%s\nwant\n%s", b.String(), want)
+	}
+}
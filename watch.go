@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+)
+
+const (
+	// watchInterval is how often -watch polls the input file.
+	watchInterval = 250 * time.Millisecond
+	// watchDebounce is how long a changed file must stay unchanged before it is re-read, so an
+	// editor writing it in several steps triggers a single re-run.
+	watchDebounce = 500 * time.Millisecond
+)
+
+// clearScreen moves the cursor home and clears the terminal.
+const clearScreen = "\033[H\033[2J"
+
+// fileDigest hashes the contents of a file, reporting false if it can't be read, e.g. while an
+// editor replaces it.
+func fileDigest(name string) ([sha256.Size]byte, bool) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return [sha256.Size]byte{}, false
+	}
+	return sha256.Sum256(b), true
+}
+
+// watchFile clears w and calls run once, then again whenever the contents of the file change,
+// polling every interval and waiting until the file has been stable for debounce. Saving the
+// file without changing it doesn't re-run, and a removed file is waited for until it is
+// re-created. Errors from run are logged without stopping the watch, which ends when ctx is done.
+func watchFile(ctx context.Context, w io.Writer, logger *slog.Logger, name string, interval, debounce time.Duration, run func() error) error {
+	rerun := func() {
+		_, _ = fmt.Fprint(w, clearScreen)
+		if err := run(); err != nil {
+			logger.Error(err.Error())
+		}
+	}
+
+	last, ok := fileDigest(name)
+	if !ok {
+		return fmt.Errorf("%w: can't read %s to watch it", ErrInvalidArgs, name)
+	}
+	rerun()
+
+	var (
+		pending   = last // the latest contents seen, run once stable
+		changedAt time.Time
+		missing   bool
+		ticker    = time.NewTicker(interval)
+	)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		digest, ok := fileDigest(name)
+		if !ok {
+			if !missing {
+				logger.Info("file is gone, waiting for it to be re-created", "file", name)
+			}
+			missing = true
+			continue
+		}
+		missing = false
+		if digest != pending {
+			pending, changedAt = digest, time.Now()
+			continue
+		}
+		if pending != last && time.Since(changedAt) >= debounce {
+			last = pending
+			rerun()
+		}
+	}
+}
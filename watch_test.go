@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_watchFile(t *testing.T) {
+	t.Parallel()
+	name := filepath.Join(t.TempDir(), "procs.csv")
+	if err := os.WriteFile(name, []byte("1,5,0,2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		out, errOut bytes.Buffer
+		runs        = make(chan struct{}, 10)
+		ctx, cancel = context.WithCancel(context.Background())
+		done        = make(chan error)
+	)
+	defer cancel()
+	go func() {
+		done <- watchFile(ctx, &out, newLogger(&errOut, slog.LevelInfo), name, time.Millisecond, 20*time.Millisecond, func() error {
+			runs <- struct{}{}
+			return errors.New("bad schedule")
+		})
+	}()
+	wait := func(what string) {
+		t.Helper()
+		select {
+		case <-runs:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no run after %s", what)
+		}
+	}
+	wait("starting")
+
+	// rewriting the same contents doesn't re-run, changing them does
+	if err := os.WriteFile(name, []byte("1,5,0,2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(name); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if err := os.WriteFile(name, []byte("1,5,0,2\n2,3,1,1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	wait("changing the file")
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("watchFile() error = %v", err)
+	}
+	if len(runs) != 0 {
+		t.Errorf("ran %d more times, want 2 runs", len(runs))
+	}
+	if got := strings.Count(out.String(), clearScreen); got != 2 {
+		t.Errorf("cleared the screen %d times, want 2", got)
+	}
+	for _, want := range []string{"bad schedule", "is gone, waiting for it to be re-created"} {
+		if !strings.Contains(errOut.String(), want) {
+			t.Errorf("errors %q are missing %q", errOut.String(), want)
+		}
+	}
+}